@@ -2,53 +2,257 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
+	"os"
 
-	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/shogotsuneto/dapr-actor-gen/pkg/config"
 	"github.com/shogotsuneto/dapr-actor-gen/pkg/generator"
 	"github.com/shogotsuneto/dapr-actor-gen/pkg/parser"
 )
 
+// formatMappingsFlag collects repeated -type-mapping flags
+type formatMappingsFlag map[string]parser.FormatMapping
+
+func (f formatMappingsFlag) String() string {
+	return fmt.Sprintf("%d mappings", len(f))
+}
+
+func (f formatMappingsFlag) Set(value string) error {
+	format, mapping, err := parser.ParseFormatMapping(value)
+	if err != nil {
+		return err
+	}
+	f[format] = mapping
+	return nil
+}
+
+// usage is printed when the spec file and output directory are given neither as arguments nor in a config file
+const usage = "Usage: generator [flags] <openapi-file> <base-output-dir>\n" +
+	"The spec may be OpenAPI 3.0, OpenAPI 3.1 or Swagger 2.0. The arguments may be omitted when the config file sets input and output.\n" +
+	"Flags:\n" +
+	"  -config           Path of the config file (default " + config.FileName + " in the working directory)\n" +
+	"  -generate-impl    Generate partial implementation stubs with not-implemented errors\n" +
+	"  -merge-impl       Add stubs for new methods to an existing impl.go, keeping existing code\n" +
+	"  -generate-example Generate example main.go, go.mod and other files for a complete app\n" +
+	"  -optional-pointers Generate optional and nullable fields as pointer types\n" +
+	"  -generate-mock    Generate mock.go with a test double implementing the actor and client interfaces\n" +
+	"  -check            Print a diff and exit non-zero if generated code in the output directory is out of date\n" +
+	"  -shared-types     Emit types used by more than one actor into a shared types package\n" +
+	"  -module           Go import path of the output directory (default example-dapr-actors)\n" +
+	"  -detect-module    Derive -module from the go.mod enclosing the output directory\n" +
+	"  -generate-validation Generate Validate() methods from schema constraints and validate requests\n" +
+	"  -transport        Transport of the example application: http or http-chi (default http-chi)\n" +
+	"  -templates        Directory with template overrides and additional actor/ and model/ templates\n" +
+	"  -type-mapping      Map an OpenAPI format to a Go type, e.g. decimal=github.com/shopspring/decimal.Decimal (repeatable)"
+
 func main() {
+	typeMappings := formatMappingsFlag{}
+	flag.Var(typeMappings, "type-mapping", "Map an OpenAPI format to a Go type, e.g. decimal=github.com/shopspring/decimal.Decimal (repeatable)")
 	var generateImpl = flag.Bool("generate-impl", false, "Generate partial implementation stubs with not-implemented errors")
+	var mergeImpl = flag.Bool("merge-impl", false, "Add stubs for new methods to an existing impl.go, keeping existing code (implies -generate-impl)")
 	var generateExample = flag.Bool("generate-example", false, "Generate example main.go, go.mod and other files for a complete app")
+	var optionalPointers = flag.Bool("optional-pointers", false, "Generate optional and nullable fields as pointer types")
+	var generateMock = flag.Bool("generate-mock", false, "Generate mock.go with a test double implementing the actor and client interfaces")
+	var check = flag.Bool("check", false, "Compare generated code with the output directory without writing, print a diff and exit non-zero if it is out of date")
+	var sharedTypes = flag.Bool("shared-types", false, "Emit types used by more than one actor into a shared types package")
+	var modulePath = flag.String("module", "example-dapr-actors", "Go import path of the output directory, used for imports between generated packages")
+	var detectModule = flag.Bool("detect-module", false, "Derive -module from the go.mod enclosing the output directory and generate no go.mod for the example")
+	var generateValidation = flag.Bool("generate-validation", false, "Generate Validate() methods from schema constraints and validate requests before invoking actor methods")
+	var transport = flag.String("transport", generator.TransportHTTPChi, "Transport of the example application: http or http-chi")
+	var templatesDir = flag.String("templates", "", "Directory with templates overriding the built-in ones of the same name, plus additional templates in actor/ and model/")
+	var configPath = flag.String("config", "", "Path of the config file (default "+config.FileName+" in the working directory, if present)")
 	flag.Parse()
 
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Flags given on the command line override the config file
+	explicit := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+	boolSetting := func(name string, flagValue *bool, configValue bool) bool {
+		if explicit[name] {
+			return *flagValue
+		}
+		return configValue
+	}
+
+	exampleTransport := *transport
+	if !explicit["transport"] && cfg.Transport != "" {
+		exampleTransport = cfg.Transport
+	}
+	if err := generator.ValidateTransport(exampleTransport); err != nil {
+		log.Fatal(err)
+	}
+
+	specFiles := cfg.Input
+	baseOutputDir := cfg.Output
 	args := flag.Args()
-	if len(args) < 2 {
-		log.Fatal("Usage: generator [flags] <openapi-file> <base-output-dir>\n" +
-			"Flags:\n" +
-			"  -generate-impl    Generate partial implementation stubs with not-implemented errors\n" +
-			"  -generate-example Generate example main.go, go.mod and other files for a complete app")
+	if len(args) > 0 {
+		if len(args) != 2 {
+			log.Fatal(usage)
+		}
+		specFiles = args[:1]
+		baseOutputDir = args[1]
+	}
+	if len(specFiles) == 0 || baseOutputDir == "" {
+		log.Fatal(usage)
 	}
 
-	schemaFile := args[0]
-	baseOutputDir := args[1]
+	mappings := cfg.FormatMappings()
+	for format, mapping := range typeMappings {
+		mappings[format] = mapping
+	}
 
-	// Load OpenAPI spec
-	loader := openapi3.NewLoader()
-	doc, err := loader.LoadFromFile(schemaFile)
+	// Load and parse every spec into one intermediate model
+	model, err := loadModel(specFiles, mappings)
 	if err != nil {
-		log.Fatalf("Failed to load OpenAPI spec: %v", err)
+		log.Fatal(err)
+	}
+	if err := applyActorOverrides(model, cfg.Actors); err != nil {
+		log.Fatalf("Invalid config: %v", err)
 	}
 
-	// Parse OpenAPI to intermediate model
-	p := parser.NewOpenAPIParser(doc)
-	model, err := p.Parse()
-	if err != nil {
-		log.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	module := *modulePath
+	if !explicit["module"] && cfg.Module != "" {
+		module = cfg.Module
+	}
+	inModule := boolSetting("detect-module", detectModule, cfg.DetectModule)
+	if explicit["module"] {
+		if explicit["detect-module"] && *detectModule {
+			log.Fatal("-module and -detect-module cannot be combined")
+		}
+		inModule = false
+	}
+	if inModule {
+		module, err = generator.DetectModulePath(baseOutputDir)
+		if err != nil {
+			log.Fatalf("Failed to detect the module path: %v", err)
+		}
+		if !*check {
+			fmt.Printf("Using module path %s\n", module)
+		}
+	}
+	templates := cfg.Templates
+	if explicit["templates"] {
+		templates = *templatesDir
 	}
 
 	// Create generation options
 	options := generator.GenerationOptions{
-		GenerateImpl:    *generateImpl,
-		GenerateExample: *generateExample,
+		GenerateImpl:       boolSetting("generate-impl", generateImpl, cfg.Generate.Impl),
+		MergeImpl:          boolSetting("merge-impl", mergeImpl, cfg.Generate.MergeImpl),
+		GenerateMock:       boolSetting("generate-mock", generateMock, cfg.Generate.Mock),
+		GenerateExample:    boolSetting("generate-example", generateExample, cfg.Generate.Example),
+		OptionalPointers:   boolSetting("optional-pointers", optionalPointers, cfg.OptionalPointers),
+		GenerateValidation: boolSetting("generate-validation", generateValidation, cfg.Generate.Validation),
+		SharedTypes:        boolSetting("shared-types", sharedTypes, cfg.SharedTypes),
+		ModulePath:         module,
+		PackageSuffix:      cfg.PackageSuffix,
+		PackageNames:       cfg.PackageNames(),
+		TemplatesDir:       templates,
+		InModule:           inModule,
+		Transport:          exampleTransport,
 	}
 
-	// Generate actor-specific packages using the intermediate model
 	gen := &generator.Generator{}
+
+	// In check mode compare the rendered output with the files on disk instead of writing them
+	if *check {
+		outdated, err := gen.CheckActorPackages(model, baseOutputDir, options, os.Stdout)
+		if err != nil {
+			log.Fatalf("Failed to check actor packages: %v", err)
+		}
+		if len(outdated) > 0 {
+			log.Fatalf("%d generated file(s) in %s are out of date; re-run the generator without -check", len(outdated), baseOutputDir)
+		}
+		fmt.Printf("Generated code in %s is up to date\n", baseOutputDir)
+		return
+	}
+
+	// Generate actor-specific packages using the intermediate model
 	err = gen.GenerateActorPackages(model, baseOutputDir, options)
 	if err != nil {
 		log.Fatalf("Failed to generate actor packages: %v", err)
 	}
 }
+
+// loadConfig loads the given config file, or the one in the working directory if no path is given.
+// Without a config file every setting comes from the command line.
+func loadConfig(path string) (*config.Config, error) {
+	if path == "" {
+		found, err := config.Find(".")
+		if err != nil {
+			return nil, err
+		}
+		if found == "" {
+			return &config.Config{}, nil
+		}
+		path = found
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("Using config file %s\n", path)
+	return cfg, nil
+}
+
+// loadModel parses the spec files into a single intermediate model. Actor types must be unique across the specs.
+func loadModel(specFiles []string, mappings map[string]parser.FormatMapping) (*generator.GenerationModel, error) {
+	model := &generator.GenerationModel{Imports: make(map[string]string)}
+	declaredIn := make(map[string]string)
+	for _, specFile := range specFiles {
+		doc, warnings, err := parser.LoadSpec(specFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load OpenAPI spec %s: %v", specFile, err)
+		}
+		for _, warning := range warnings {
+			fmt.Printf("warning: %s: %s\n", specFile, warning)
+		}
+
+		specModel, err := parser.NewOpenAPIParser(doc).WithFormatMappings(mappings).Parse()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse OpenAPI spec %s: %v", specFile, err)
+		}
+
+		for _, actor := range specModel.Actors {
+			if other, exists := declaredIn[actor.ActorType]; exists {
+				return nil, fmt.Errorf("actor type %s is declared in both %s and %s", actor.ActorType, other, specFile)
+			}
+			declaredIn[actor.ActorType] = specFile
+			model.Actors = append(model.Actors, actor)
+		}
+		for qualifier, importPath := range specModel.Imports {
+			model.Imports[qualifier] = importPath
+		}
+	}
+	return model, nil
+}
+
+// applyActorOverrides removes the actors skipped by the config. Overrides must name actors of the model.
+func applyActorOverrides(model *generator.GenerationModel, overrides map[string]config.Actor) error {
+	for actorType := range overrides {
+		known := false
+		for _, actor := range model.Actors {
+			known = known || actor.ActorType == actorType
+		}
+		if !known {
+			return fmt.Errorf("actors.%s: no actor of this type is declared in the spec", actorType)
+		}
+	}
+
+	actors := model.Actors[:0]
+	for _, actor := range model.Actors {
+		if !overrides[actor.ActorType].Skip {
+			actors = append(actors, actor)
+		}
+	}
+	model.Actors = actors
+	return nil
+}
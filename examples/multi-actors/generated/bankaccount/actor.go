@@ -47,7 +47,7 @@ func (a *BankAccount) appendEvent(ctx context.Context, eventType AccountEventEve
 	event := AccountEvent{
 		EventId:   uuid.New().String(),
 		EventType: eventType,
-		Timestamp: time.Now().Format(time.RFC3339),
+		Timestamp: time.Now(),
 		Data:      eventData,
 	}
 	
@@ -122,11 +122,7 @@ func (a *BankAccount) CreateAccount(ctx context.Context, request CreateAccountRe
 
 // Deposit deposits money to account
 func (a *BankAccount) Deposit(ctx context.Context, request DepositRequest) (*BankAccountState, error) {
-	// Validate request
-	if request.Amount <= 0 {
-		return nil, fmt.Errorf("deposit amount must be positive")
-	}
-	
+	// The amount range is enforced by the generated DepositRequest.Validate
 	eventData := map[string]interface{}{
 		"amount":      request.Amount,
 		"description": request.Description,
@@ -164,13 +160,16 @@ func (a *BankAccount) Withdraw(ctx context.Context, request WithdrawRequest) (*B
 		return nil, err
 	}
 	
-	// Validate request
-	if request.Amount <= 0 {
-		return nil, fmt.Errorf("withdrawal amount must be positive")
-	}
+	// The amount range is enforced by the generated WithdrawRequest.Validate
 	if request.Amount > currentState.Balance {
-		return nil, fmt.Errorf("insufficient funds: current balance %.2f, requested %.2f", 
-			currentState.Balance, request.Amount)
+		// Typed error declared in the spec; clients can match it with errors.As
+		return nil, &InsufficientFundsError{
+			StatusCode: 400,
+			Body: InsufficientFunds{
+				Balance:   currentState.Balance,
+				Requested: request.Amount,
+			},
+		}
 	}
 	
 	eventData := map[string]interface{}{
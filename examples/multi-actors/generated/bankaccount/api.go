@@ -5,6 +5,7 @@ package bankaccount
 
 import (
 	"context"
+
 	"github.com/dapr/go-sdk/actor"
 )
 
@@ -24,5 +25,6 @@ type BankAccountAPI interface {
 	// Get transaction history
 	GetHistory(ctx context.Context) (*TransactionHistory, error)
 	// Withdraw money from account
+	// Fails with *InsufficientFundsError for status 400: Insufficient funds
 	Withdraw(ctx context.Context, request WithdrawRequest) (*BankAccountState, error)
-}
\ No newline at end of file
+}
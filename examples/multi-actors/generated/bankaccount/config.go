@@ -0,0 +1,55 @@
+// Package bankaccount provides primitives for OpenAPI-based schema validation.
+//
+// Code generated from OpenAPI specification. DO NOT EDIT manually.
+package bankaccount
+
+import (
+	"github.com/dapr/go-sdk/actor/config"
+)
+
+// RuntimeConfig holds the Dapr runtime settings declared for an actor type. Empty values keep the Dapr defaults.
+// Apart from the serializer the settings are informational: the generated code does not apply them.
+type RuntimeConfig struct {
+	// IdleTimeout is how long an actor may stay idle before it is deactivated, e.g. "1h"
+	IdleTimeout string
+	// ScanInterval is how often Dapr looks for idle actors to deactivate, e.g. "30s"
+	ScanInterval string
+	// DrainOngoingCallTimeout is how long in-flight calls may run while actors are rebalanced
+	DrainOngoingCallTimeout string
+	// DrainRebalancedActors waits for in-flight calls before rebalanced actors are deactivated
+	DrainRebalancedActors bool
+	// ReentrancyEnabled allows calls of the same call chain to re-enter the actor
+	ReentrancyEnabled bool
+	// ReentrancyMaxStackDepth limits the depth of reentrant calls (0 keeps the Dapr default)
+	ReentrancyMaxStackDepth int
+	// RemindersStoragePartitions is the number of partitions the reminders are stored in (0 keeps a single key)
+	RemindersStoragePartitions int
+	// Serializer is the name of the serializer of the actor's state and messages
+	Serializer string
+}
+
+// Config returns the runtime settings of the BankAccount actor declared in the OpenAPI specification
+func Config() RuntimeConfig {
+	return RuntimeConfig{
+		IdleTimeout:             "1h",
+		ScanInterval:            "30s",
+		DrainOngoingCallTimeout: "30s",
+		DrainRebalancedActors:   true,
+	}
+}
+
+// Options returns the actor options to register the actor with:
+//
+//	s.RegisterActorImplFactoryContext(NewActorFactory(), Options()...)
+//
+// Only the serializer is set, as it is the only per actor type setting of the Go SDK. The idle timeout,
+// scan interval, drain, reentrancy and reminder storage settings are not applied; Dapr reads them from
+// the application's /dapr/config response, so configure the application with the values from Config.
+func Options() []config.Option {
+	cfg := Config()
+	var options []config.Option
+	if cfg.Serializer != "" {
+		options = append(options, config.WithSerializerName(cfg.Serializer))
+	}
+	return options
+}
@@ -0,0 +1,73 @@
+// Package bankaccount provides primitives for OpenAPI-based schema validation.
+//
+// Code generated from OpenAPI specification. DO NOT EDIT manually.
+package bankaccount
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// actorErrorMarker precedes the JSON encoding of a typed error in its message. The message is what
+// reaches the caller of an actor method, so DecodeError can restore the typed error from it.
+const actorErrorMarker = "dapr-actor-error:"
+
+// actorErrorEnvelope is the encoding of a typed error in its message
+type actorErrorEnvelope struct {
+	Type       string          `json:"type"`
+	StatusCode int             `json:"status"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// encodeActorError renders the message of a typed error, embedding the status code and body
+func encodeActorError(typeName string, statusCode int, body interface{}) string {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Sprintf("%s (status %d): failed to encode body: %v", typeName, statusCode, err)
+	}
+	envelope, _ := json.Marshal(actorErrorEnvelope{Type: typeName, StatusCode: statusCode, Body: data})
+	return fmt.Sprintf("%s (status %d): %s%s", typeName, statusCode, actorErrorMarker, envelope)
+}
+
+// InsufficientFundsError is the error documented with status 400.
+// Return it from an actor method to send the InsufficientFunds body to the caller.
+type InsufficientFundsError struct {
+	StatusCode int
+	Body       InsufficientFunds
+}
+
+// Error encodes the status code and body so that the client can decode them with DecodeError
+func (e *InsufficientFundsError) Error() string {
+	return encodeActorError("InsufficientFundsError", e.StatusCode, e.Body)
+}
+
+// DecodeError restores the typed error embedded in the message of err, e.g. an error returned by the
+// invocation of an actor method. Errors without an embedded typed error are returned unchanged.
+func DecodeError(err error) error {
+	if err == nil {
+		return nil
+	}
+	message := err.Error()
+	start := strings.Index(message, actorErrorMarker)
+	if start < 0 {
+		return err
+	}
+
+	// The encoded error may be followed by text added while the error was passed on
+	var envelope actorErrorEnvelope
+	decoder := json.NewDecoder(strings.NewReader(message[start+len(actorErrorMarker):]))
+	if decoder.Decode(&envelope) != nil {
+		return err
+	}
+
+	switch envelope.Type {
+	case "InsufficientFundsError":
+		decoded := &InsufficientFundsError{StatusCode: envelope.StatusCode}
+		if json.Unmarshal(envelope.Body, &decoded.Body) != nil {
+			return err
+		}
+		return decoded
+	}
+	return err
+}
@@ -4,7 +4,9 @@
 package bankaccount
 
 import (
+	"context"
 	"fmt"
+
 	"github.com/dapr/go-sdk/actor"
 )
 
@@ -15,15 +17,54 @@ func NewActorFactory() func() actor.ServerContext {
 	return func() actor.ServerContext {
 		// Create a new BankAccount instance
 		impl := &BankAccount{}
-		
+
 		// Compile-time check ensures the implementation satisfies the schema
 		var _ BankAccountAPI = impl
-		
+
 		// Verify the actor type matches the schema
 		if impl.Type() != ActorTypeBankAccount {
 			panic(fmt.Sprintf("actor implementation Type() returns '%s', expected '%s'", impl.Type(), ActorTypeBankAccount))
 		}
-		
-		return impl
+
+		// Validate requests against the schema constraints before they reach the implementation
+		return &ValidatingBankAccount{impl}
+	}
+}
+
+// ValidatingBankAccount wraps a BankAccountAPI implementation and rejects requests
+// that violate the constraints declared in the OpenAPI specification. It is exported because the Dapr Go SDK
+// only dispatches method calls to actors of exported types.
+type ValidatingBankAccount struct {
+	BankAccountAPI
+}
+
+// CreateAccount validates the request before invoking the implementation
+func (a *ValidatingBankAccount) CreateAccount(ctx context.Context, request CreateAccountRequest) (*BankAccountState, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	return a.BankAccountAPI.CreateAccount(ctx, request)
+}
+
+// Deposit validates the request before invoking the implementation
+func (a *ValidatingBankAccount) Deposit(ctx context.Context, request DepositRequest) (*BankAccountState, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	return a.BankAccountAPI.Deposit(ctx, request)
+}
+
+// Withdraw validates the request before invoking the implementation
+func (a *ValidatingBankAccount) Withdraw(ctx context.Context, request WithdrawRequest) (*BankAccountState, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	return a.BankAccountAPI.Withdraw(ctx, request)
+}
+
+// ReminderCall forwards reminder callbacks when the implementation handles them
+func (a *ValidatingBankAccount) ReminderCall(reminderName string, state []byte, dueTime string, period string) {
+	if callee, ok := a.BankAccountAPI.(actor.ReminderCallee); ok {
+		callee.ReminderCall(reminderName, state, dueTime, period)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,70 @@
+// Package bankaccount provides primitives for OpenAPI-based schema validation.
+//
+// Code generated from OpenAPI specification. DO NOT EDIT manually.
+package bankaccount
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dapr/go-sdk/actor"
+)
+
+// State keys of the BankAccount actor declared in the OpenAPI specification
+const (
+	StateKeyEvents = "events"
+)
+
+// State provides typed access to the state of a BankAccount actor through the Dapr state manager.
+// Changes are kept by the state manager and saved by Dapr after the actor method returns.
+type State struct {
+	sm actor.StateManagerContext
+}
+
+// NewState creates typed state accessors for an actor's state manager.
+// Usage: state := NewState(a.GetStateManager())
+func NewState(sm actor.StateManagerContext) *State {
+	return &State{sm: sm}
+}
+
+// GetEvents returns the "events" state, or the zero value if it has not been set.
+// Events of the account in the order they occurred
+func (s *State) GetEvents(ctx context.Context) ([]AccountEvent, error) {
+	var value []AccountEvent
+	exists, err := s.sm.Contains(ctx, StateKeyEvents)
+	if err != nil {
+		return value, fmt.Errorf("failed to look up state %s: %w", StateKeyEvents, err)
+	}
+	if !exists {
+		return value, nil
+	}
+	if err := s.sm.Get(ctx, StateKeyEvents, &value); err != nil {
+		return value, fmt.Errorf("failed to get state %s: %w", StateKeyEvents, err)
+	}
+	return value, nil
+}
+
+// HasEvents reports whether the "events" state has been set
+func (s *State) HasEvents(ctx context.Context) (bool, error) {
+	exists, err := s.sm.Contains(ctx, StateKeyEvents)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up state %s: %w", StateKeyEvents, err)
+	}
+	return exists, nil
+}
+
+// SetEvents stores the "events" state
+func (s *State) SetEvents(ctx context.Context, value []AccountEvent) error {
+	if err := s.sm.Set(ctx, StateKeyEvents, value); err != nil {
+		return fmt.Errorf("failed to set state %s: %w", StateKeyEvents, err)
+	}
+	return nil
+}
+
+// DeleteEvents removes the "events" state
+func (s *State) DeleteEvents(ctx context.Context) error {
+	if err := s.sm.Remove(ctx, StateKeyEvents); err != nil {
+		return fmt.Errorf("failed to delete state %s: %w", StateKeyEvents, err)
+	}
+	return nil
+}
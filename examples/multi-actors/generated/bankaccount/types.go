@@ -3,6 +3,12 @@
 // Code generated from OpenAPI specification. DO NOT EDIT manually.
 package bankaccount
 
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
 
 // AccountEvent A single account event
 type AccountEvent struct {
@@ -13,7 +19,7 @@ type AccountEvent struct {
 	// Type of event
 	EventType AccountEventEventType `json:"eventType"`
 	// When the event occurred
-	Timestamp string `json:"timestamp"`
+	Timestamp time.Time `json:"timestamp"`
 }
 
 // BankAccountState Current state of bank account (computed from events)
@@ -23,7 +29,7 @@ type BankAccountState struct {
 	// Current account balance (computed from events)
 	Balance float64 `json:"balance"`
 	// Account creation timestamp
-	CreatedAt string `json:"createdAt,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
 	// Whether account is active
 	IsActive bool `json:"isActive"`
 	// Account owner name
@@ -46,6 +52,14 @@ type DepositRequest struct {
 	Description string `json:"description"`
 }
 
+// InsufficientFunds Details of a withdrawal rejected because the balance is too low
+type InsufficientFunds struct {
+	// Current account balance
+	Balance float64 `json:"balance"`
+	// Requested withdrawal amount
+	Requested float64 `json:"requested"`
+}
+
 // TransactionHistory Complete transaction history (event sourcing benefit)
 type TransactionHistory struct {
 	// Account identifier
@@ -62,10 +76,6 @@ type WithdrawRequest struct {
 	Description string `json:"description"`
 }
 
-
-
-
-
 // AccountEventEventType defines valid values for AccountEvent.eventType
 type AccountEventEventType string
 
@@ -75,3 +85,178 @@ const (
 	AccountEventEventTypeMoneyDeposited AccountEventEventType = "MoneyDeposited"
 	AccountEventEventTypeMoneyWithdrawn AccountEventEventType = "MoneyWithdrawn"
 )
+
+// IsValid reports whether v is one of the AccountEventEventType values declared in the OpenAPI specification
+func (v AccountEventEventType) IsValid() bool {
+	switch v {
+	case AccountEventEventTypeAccountCreated, AccountEventEventTypeMoneyDeposited, AccountEventEventTypeMoneyWithdrawn:
+		return true
+	}
+	return false
+}
+
+// ValidationError describes a single constraint violation, identified by its JSON field path
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+// Error implements the error interface
+func (e ValidationError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors aggregates all constraint violations found while validating a value
+type ValidationErrors []ValidationError
+
+// Error implements the error interface
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// add records a constraint violation for the given field path
+func (e *ValidationErrors) add(field, message string) {
+	*e = append(*e, ValidationError{Field: field, Message: message})
+}
+
+// Validate checks AccountEvent against the constraints declared in the OpenAPI specification
+// and returns ValidationErrors listing every violation.
+func (v *AccountEvent) Validate() error {
+	var errs ValidationErrors
+	v.validate("", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validate appends the constraint violations of AccountEvent to errs, prefixing field names with path
+func (v *AccountEvent) validate(path string, errs *ValidationErrors) {
+	if v.Data == nil {
+		errs.add(path+"data", "is required")
+	}
+	if !v.EventType.IsValid() {
+		errs.add(path+"eventType", "must be one of AccountCreated, MoneyDeposited, MoneyWithdrawn")
+	}
+}
+
+// Validate checks BankAccountState against the constraints declared in the OpenAPI specification
+// and returns ValidationErrors listing every violation.
+func (v *BankAccountState) Validate() error {
+	var errs ValidationErrors
+	v.validate("", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validate appends the constraint violations of BankAccountState to errs, prefixing field names with path
+func (v *BankAccountState) validate(path string, errs *ValidationErrors) {
+}
+
+// Validate checks CreateAccountRequest against the constraints declared in the OpenAPI specification
+// and returns ValidationErrors listing every violation.
+func (v *CreateAccountRequest) Validate() error {
+	var errs ValidationErrors
+	v.validate("", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validate appends the constraint violations of CreateAccountRequest to errs, prefixing field names with path
+func (v *CreateAccountRequest) validate(path string, errs *ValidationErrors) {
+	if float64(v.InitialDeposit) < 0 {
+		errs.add(path+"initialDeposit", "must be greater than or equal to 0")
+	}
+	if utf8.RuneCountInString(string(v.OwnerName)) < 1 {
+		errs.add(path+"ownerName", "must be at least 1 characters long")
+	}
+	if utf8.RuneCountInString(string(v.OwnerName)) > 100 {
+		errs.add(path+"ownerName", "must be at most 100 characters long")
+	}
+}
+
+// Validate checks DepositRequest against the constraints declared in the OpenAPI specification
+// and returns ValidationErrors listing every violation.
+func (v *DepositRequest) Validate() error {
+	var errs ValidationErrors
+	v.validate("", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validate appends the constraint violations of DepositRequest to errs, prefixing field names with path
+func (v *DepositRequest) validate(path string, errs *ValidationErrors) {
+	if float64(v.Amount) < 0.01 {
+		errs.add(path+"amount", "must be greater than or equal to 0.01")
+	}
+	if utf8.RuneCountInString(string(v.Description)) > 200 {
+		errs.add(path+"description", "must be at most 200 characters long")
+	}
+}
+
+// Validate checks InsufficientFunds against the constraints declared in the OpenAPI specification
+// and returns ValidationErrors listing every violation.
+func (v *InsufficientFunds) Validate() error {
+	var errs ValidationErrors
+	v.validate("", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validate appends the constraint violations of InsufficientFunds to errs, prefixing field names with path
+func (v *InsufficientFunds) validate(path string, errs *ValidationErrors) {
+}
+
+// Validate checks TransactionHistory against the constraints declared in the OpenAPI specification
+// and returns ValidationErrors listing every violation.
+func (v *TransactionHistory) Validate() error {
+	var errs ValidationErrors
+	v.validate("", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validate appends the constraint violations of TransactionHistory to errs, prefixing field names with path
+func (v *TransactionHistory) validate(path string, errs *ValidationErrors) {
+	if v.Events == nil {
+		errs.add(path+"events", "is required")
+	}
+	for i := range v.Events {
+		v.Events[i].validate(path+"events"+"["+strconv.Itoa(i)+"].", errs)
+	}
+}
+
+// Validate checks WithdrawRequest against the constraints declared in the OpenAPI specification
+// and returns ValidationErrors listing every violation.
+func (v *WithdrawRequest) Validate() error {
+	var errs ValidationErrors
+	v.validate("", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validate appends the constraint violations of WithdrawRequest to errs, prefixing field names with path
+func (v *WithdrawRequest) validate(path string, errs *ValidationErrors) {
+	if float64(v.Amount) < 0.01 {
+		errs.add(path+"amount", "must be greater than or equal to 0.01")
+	}
+	if utf8.RuneCountInString(string(v.Description)) > 200 {
+		errs.add(path+"description", "must be at most 200 characters long")
+	}
+}
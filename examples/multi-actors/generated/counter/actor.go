@@ -117,3 +117,10 @@ func (a *Counter) Set(ctx context.Context, request SetValueRequest) (*CounterSta
 		LastOperation: CounterOperationSet,
 	}, nil
 }
+
+// OnReset is called when the "reset" reminder fires
+func (a *Counter) OnReset(ctx context.Context) error {
+	a.setValue(ctx, 0)
+	log.Printf("[Counter] Reset by reminder")
+	return nil
+}
@@ -5,6 +5,7 @@ package counter
 
 import (
 	"context"
+
 	"github.com/dapr/go-sdk/actor"
 )
 
@@ -23,4 +24,6 @@ type CounterAPI interface {
 	Increment(ctx context.Context) (*CounterState, error)
 	// Set counter to specific value
 	Set(ctx context.Context, request SetValueRequest) (*CounterState, error)
-}
\ No newline at end of file
+	// OnReset is called when the "reset" reminder fires: Resets the counter to zero
+	OnReset(ctx context.Context) error
+}
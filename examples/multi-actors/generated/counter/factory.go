@@ -4,7 +4,9 @@
 package counter
 
 import (
+	"context"
 	"fmt"
+
 	"github.com/dapr/go-sdk/actor"
 )
 
@@ -15,15 +17,38 @@ func NewActorFactory() func() actor.ServerContext {
 	return func() actor.ServerContext {
 		// Create a new Counter instance
 		impl := &Counter{}
-		
+
 		// Compile-time check ensures the implementation satisfies the schema
 		var _ CounterAPI = impl
-		
+
 		// Verify the actor type matches the schema
 		if impl.Type() != ActorTypeCounter {
 			panic(fmt.Sprintf("actor implementation Type() returns '%s', expected '%s'", impl.Type(), ActorTypeCounter))
 		}
-		
-		return impl
+
+		// Validate requests against the schema constraints and dispatch reminders to their typed callbacks
+		return &RemindingCounter{&ValidatingCounter{impl}}
+	}
+}
+
+// ValidatingCounter wraps a CounterAPI implementation and rejects requests
+// that violate the constraints declared in the OpenAPI specification. It is exported because the Dapr Go SDK
+// only dispatches method calls to actors of exported types.
+type ValidatingCounter struct {
+	CounterAPI
+}
+
+// Set validates the request before invoking the implementation
+func (a *ValidatingCounter) Set(ctx context.Context, request SetValueRequest) (*CounterState, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	return a.CounterAPI.Set(ctx, request)
+}
+
+// ReminderCall forwards reminder callbacks when the implementation handles them
+func (a *ValidatingCounter) ReminderCall(reminderName string, state []byte, dueTime string, period string) {
+	if callee, ok := a.CounterAPI.(actor.ReminderCallee); ok {
+		callee.ReminderCall(reminderName, state, dueTime, period)
 	}
-}
\ No newline at end of file
+}
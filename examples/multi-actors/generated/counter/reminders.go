@@ -0,0 +1,70 @@
+// Package counter provides primitives for OpenAPI-based schema validation.
+//
+// Code generated from OpenAPI specification. DO NOT EDIT manually.
+package counter
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	dapr "github.com/dapr/go-sdk/client"
+)
+
+// Names of the reminders and timers of the Counter actor declared in the OpenAPI specification
+const (
+	ReminderReset = "reset"
+)
+
+// DispatchReminder decodes the payload of a reminder and invokes its typed callback on the implementation
+func DispatchReminder(ctx context.Context, impl CounterAPI, reminderName string, state []byte) error {
+	switch reminderName {
+	case ReminderReset:
+		return impl.OnReset(ctx)
+	default:
+		return fmt.Errorf("unknown reminder %s of actor type %s", reminderName, ActorTypeCounter)
+	}
+}
+
+// RemindingCounter wraps a CounterAPI implementation and dispatches Dapr reminders
+// to their typed callbacks. It is exported because the Dapr Go SDK only dispatches calls to actors of exported types.
+type RemindingCounter struct {
+	CounterAPI
+}
+
+// ReminderCall dispatches a reminder to its typed callback. Dapr does not take an error from reminder
+// callbacks, so failures are logged.
+func (a *RemindingCounter) ReminderCall(reminderName string, state []byte, dueTime string, period string) {
+	if err := DispatchReminder(context.Background(), a.CounterAPI, reminderName, state); err != nil {
+		log.Printf("%s reminder %s failed: %v", ActorTypeCounter, reminderName, err)
+	}
+}
+
+// RegisterResetReminder registers the "reset" reminder of the actor with Dapr.
+// dueTime and period use the Dapr formats, e.g. "5s", "1h" or an ISO 8601 duration.
+func (c *CounterClient) RegisterResetReminder(ctx context.Context, dueTime, period string) error {
+	err := c.client.RegisterActorReminder(ctx, &dapr.RegisterActorReminderRequest{
+		ActorType: ActorTypeCounter,
+		ActorID:   c.actorID,
+		Name:      ReminderReset,
+		DueTime:   dueTime,
+		Period:    period,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register %s reminder: %w", ReminderReset, err)
+	}
+	return nil
+}
+
+// UnregisterResetReminder removes the "reset" reminder of the actor
+func (c *CounterClient) UnregisterResetReminder(ctx context.Context) error {
+	err := c.client.UnregisterActorReminder(ctx, &dapr.UnregisterActorReminderRequest{
+		ActorType: ActorTypeCounter,
+		ActorID:   c.actorID,
+		Name:      ReminderReset,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unregister %s reminder: %w", ReminderReset, err)
+	}
+	return nil
+}
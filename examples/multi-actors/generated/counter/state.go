@@ -0,0 +1,70 @@
+// Package counter provides primitives for OpenAPI-based schema validation.
+//
+// Code generated from OpenAPI specification. DO NOT EDIT manually.
+package counter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dapr/go-sdk/actor"
+)
+
+// State keys of the Counter actor declared in the OpenAPI specification
+const (
+	StateKeyValue = "value"
+)
+
+// State provides typed access to the state of a Counter actor through the Dapr state manager.
+// Changes are kept by the state manager and saved by Dapr after the actor method returns.
+type State struct {
+	sm actor.StateManagerContext
+}
+
+// NewState creates typed state accessors for an actor's state manager.
+// Usage: state := NewState(a.GetStateManager())
+func NewState(sm actor.StateManagerContext) *State {
+	return &State{sm: sm}
+}
+
+// GetValue returns the "value" state, or the zero value if it has not been set.
+// Current counter value
+func (s *State) GetValue(ctx context.Context) (int32, error) {
+	var value int32
+	exists, err := s.sm.Contains(ctx, StateKeyValue)
+	if err != nil {
+		return value, fmt.Errorf("failed to look up state %s: %w", StateKeyValue, err)
+	}
+	if !exists {
+		return value, nil
+	}
+	if err := s.sm.Get(ctx, StateKeyValue, &value); err != nil {
+		return value, fmt.Errorf("failed to get state %s: %w", StateKeyValue, err)
+	}
+	return value, nil
+}
+
+// HasValue reports whether the "value" state has been set
+func (s *State) HasValue(ctx context.Context) (bool, error) {
+	exists, err := s.sm.Contains(ctx, StateKeyValue)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up state %s: %w", StateKeyValue, err)
+	}
+	return exists, nil
+}
+
+// SetValue stores the "value" state
+func (s *State) SetValue(ctx context.Context, value int32) error {
+	if err := s.sm.Set(ctx, StateKeyValue, value); err != nil {
+		return fmt.Errorf("failed to set state %s: %w", StateKeyValue, err)
+	}
+	return nil
+}
+
+// DeleteValue removes the "value" state
+func (s *State) DeleteValue(ctx context.Context) error {
+	if err := s.sm.Remove(ctx, StateKeyValue); err != nil {
+		return fmt.Errorf("failed to delete state %s: %w", StateKeyValue, err)
+	}
+	return nil
+}
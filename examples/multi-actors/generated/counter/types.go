@@ -3,6 +3,9 @@
 // Code generated from OpenAPI specification. DO NOT EDIT manually.
 package counter
 
+import (
+	"strings"
+)
 
 // CounterState Current state of the counter actor (state-based)
 type CounterState struct {
@@ -20,10 +23,6 @@ type SetValueRequest struct {
 	Value int32 `json:"value"`
 }
 
-
-
-
-
 // CounterOperation Type of the last operation performed on the counter
 type CounterOperation string
 
@@ -31,11 +30,20 @@ type CounterOperation string
 const (
 	CounterOperationIncrement CounterOperation = "increment"
 	CounterOperationDecrement CounterOperation = "decrement"
-	CounterOperationSet CounterOperation = "set"
-	CounterOperationGet CounterOperation = "get"
-	CounterOperationReset CounterOperation = "reset"
+	CounterOperationSet       CounterOperation = "set"
+	CounterOperationGet       CounterOperation = "get"
+	CounterOperationReset     CounterOperation = "reset"
 )
 
+// IsValid reports whether v is one of the CounterOperation values declared in the OpenAPI specification
+func (v CounterOperation) IsValid() bool {
+	switch v {
+	case CounterOperationIncrement, CounterOperationDecrement, CounterOperationSet, CounterOperationGet, CounterOperationReset:
+		return true
+	}
+	return false
+}
+
 // CounterStatus Current status of the counter
 type CounterStatus string
 
@@ -43,6 +51,87 @@ type CounterStatus string
 const (
 	CounterStatusActive CounterStatus = "active"
 	CounterStatusPaused CounterStatus = "paused"
-	CounterStatusError CounterStatus = "error"
-	CounterStatusReset CounterStatus = "reset"
+	CounterStatusError  CounterStatus = "error"
+	CounterStatusReset  CounterStatus = "reset"
 )
+
+// IsValid reports whether v is one of the CounterStatus values declared in the OpenAPI specification
+func (v CounterStatus) IsValid() bool {
+	switch v {
+	case CounterStatusActive, CounterStatusPaused, CounterStatusError, CounterStatusReset:
+		return true
+	}
+	return false
+}
+
+// ValidationError describes a single constraint violation, identified by its JSON field path
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+// Error implements the error interface
+func (e ValidationError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors aggregates all constraint violations found while validating a value
+type ValidationErrors []ValidationError
+
+// Error implements the error interface
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// add records a constraint violation for the given field path
+func (e *ValidationErrors) add(field, message string) {
+	*e = append(*e, ValidationError{Field: field, Message: message})
+}
+
+// Validate checks CounterState against the constraints declared in the OpenAPI specification
+// and returns ValidationErrors listing every violation.
+func (v *CounterState) Validate() error {
+	var errs ValidationErrors
+	v.validate("", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validate appends the constraint violations of CounterState to errs, prefixing field names with path
+func (v *CounterState) validate(path string, errs *ValidationErrors) {
+	if v.LastOperation != "" {
+		if !v.LastOperation.IsValid() {
+			errs.add(path+"lastOperation", "must be one of increment, decrement, set, get, reset")
+		}
+	}
+	if !v.Status.IsValid() {
+		errs.add(path+"status", "must be one of active, paused, error, reset")
+	}
+}
+
+// Validate checks SetValueRequest against the constraints declared in the OpenAPI specification
+// and returns ValidationErrors listing every violation.
+func (v *SetValueRequest) Validate() error {
+	var errs ValidationErrors
+	v.validate("", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validate appends the constraint violations of SetValueRequest to errs, prefixing field names with path
+func (v *SetValueRequest) validate(path string, errs *ValidationErrors) {
+	if float64(v.Value) < -2147483648 {
+		errs.add(path+"value", "must be greater than or equal to -2147483648")
+	}
+	if float64(v.Value) > 2147483647 {
+		errs.add(path+"value", "must be less than or equal to 2147483647")
+	}
+}
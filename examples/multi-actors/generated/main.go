@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"net/http"
 	"os"
@@ -9,9 +10,10 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/dapr/go-sdk/service/common"
+	daprd "github.com/dapr/go-sdk/service/http"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-	daprd "github.com/dapr/go-sdk/service/http"
 
 	"example-dapr-actors/bankaccount"
 	"example-dapr-actors/counter"
@@ -50,7 +52,7 @@ func contextEnrichmentMiddleware(next http.Handler) http.Handler {
 		// Add a request ID to context
 		requestID := time.Now().Format("20060102-150405.000")
 		ctx := context.WithValue(r.Context(), RequestIDKey, requestID)
-		
+
 		// Add some user info to context (simulated for demonstration)
 		userInfo := map[string]string{
 			"user":      "example-user",
@@ -58,57 +60,58 @@ func contextEnrichmentMiddleware(next http.Handler) http.Handler {
 			"timestamp": time.Now().Format(time.RFC3339),
 		}
 		ctx = context.WithValue(ctx, UserInfoKey, userInfo)
-		
+
 		log.Printf("Context enriched with RequestID: %s", requestID)
-		
+
 		// Pass the enriched context to the next handler
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
 func main() {
+	// The listen address and shutdown timeout can be set with flags or environment variables
+	defaultTimeout, err := time.ParseDuration(envOrDefault("SHUTDOWN_TIMEOUT", "15s"))
+	if err != nil {
+		log.Fatalf("Invalid SHUTDOWN_TIMEOUT: %v", err)
+	}
+	addr := flag.String("addr", envOrDefault("APP_ADDR", ":"+envOrDefault("APP_PORT", "8080")), "Address to listen on (env APP_ADDR, or APP_PORT for the port only)")
+	shutdownTimeout := flag.Duration("shutdown-timeout", defaultTimeout, "Time to wait for in-flight actor calls when shutting down (env SHUTDOWN_TIMEOUT)")
+	flag.Parse()
+
 	// Create a Chi router with middleware (DEMONSTRATION OF CHI INTEGRATION)
 	// This shows how to integrate Chi router with Dapr actors
 	// The middleware below are for demonstration purposes only
 	r := chi.NewRouter()
-	
+
 	// Add built-in Chi middleware (DEMONSTRATION ONLY)
 	// In production, configure these based on your specific requirements
-	r.Use(middleware.Logger)      // Log requests
-	r.Use(middleware.Recoverer)   // Recover from panics
-	r.Use(middleware.RequestID)   // Add request ID header
-	r.Use(middleware.RealIP)      // Set real IP
-	
+	r.Use(middleware.Logger)    // Log requests
+	r.Use(middleware.Recoverer) // Recover from panics
+	r.Use(middleware.RequestID) // Add request ID header
+	r.Use(middleware.RealIP)    // Set real IP
+
 	// Add our custom middleware (DEMONSTRATION ONLY)
 	// In production, replace with proper authentication, authorization, etc.
 	r.Use(headerLoggingMiddleware)     // Log HTTP headers
 	r.Use(contextEnrichmentMiddleware) // Enrich context with custom values
-	
+
 	// Create a Dapr service with our custom Chi router
 	// This demonstrates how to use Chi router instead of the default mux
-	s := daprd.NewServiceWithMux(":8080", r)
+	s := daprd.NewServiceWithMux(*addr, r)
 
-	// Register all generated actors
+	// Register all generated actors with the runtime settings declared in the spec
 
 	// Register BankAccount actor
-	s.RegisterActorImplFactoryContext(bankaccount.NewActorFactory())
+	s.RegisterActorImplFactoryContext(bankaccount.NewActorFactory(), bankaccount.Options()...)
 	// Register Counter actor
 	s.RegisterActorImplFactoryContext(counter.NewActorFactory())
 
-	// Setup graceful shutdown
-	go func() {
-		// Wait for interrupt signal to gracefully shutdown
-		quit := make(chan os.Signal, 1)
-		signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-		<-quit
-		log.Println("Shutting down server...")
-		
-		// The server shutdown is handled by Dapr service
-		os.Exit(0)
-	}()
+	// Stop on SIGINT/SIGTERM; a second signal terminates immediately
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
 	// Start the service
-	log.Println("Starting Dapr actor service with Chi router and custom middleware on :8080")
+	log.Printf("Starting Dapr actor service with Chi router and custom middleware on %s", *addr)
 	log.Println("NOTE: The middleware configured below are for DEMONSTRATION PURPOSES ONLY")
 	log.Println("In production, configure middleware based on your specific security and operational requirements")
 	log.Println()
@@ -119,8 +122,60 @@ func main() {
 	log.Println("- RealIP: sets real IP address")
 	log.Println("- HeaderLogging: logs all HTTP headers (DEMO ONLY - avoid in production)")
 	log.Println("- ContextEnrichment: adds custom values to request context (DEMO ONLY)")
-	
-	if err := s.Start(); err != nil && err != http.ErrServerClosed {
-		log.Fatalf("Failed to start service: %v", err)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.Start()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to start service: %v", err)
+		}
+		return
+	case <-ctx.Done():
+		stop()
+	}
+	os.Exit(shutdown(s, serveErr, *shutdownTimeout))
+}
+
+// shutdown stops the service gracefully, letting in-flight actor calls complete, and stops it forcibly
+// when they take longer than the timeout. It returns the exit status of the application.
+func shutdown(s common.Service, serveErr <-chan error, timeout time.Duration) int {
+	log.Printf("Shutting down, waiting up to %s for in-flight actor calls...", timeout)
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- s.GracefulStop()
+	}()
+
+	select {
+	case err := <-stopped:
+		if err != nil {
+			log.Printf("Graceful shutdown failed: %v", err)
+			return 1
+		}
+	case <-time.After(timeout):
+		log.Printf("In-flight actor calls did not complete within %s, stopping the service", timeout)
+		if err := s.Stop(); err != nil {
+			log.Printf("Failed to stop service: %v", err)
+		}
+		return 1
+	}
+
+	if err := <-serveErr; err != nil && err != http.ErrServerClosed {
+		log.Printf("Service stopped with error: %v", err)
+		return 1
 	}
-}
\ No newline at end of file
+	log.Println("Service stopped")
+	return 0
+}
+
+// envOrDefault returns the value of an environment variable, or the default if it is not set
+func envOrDefault(name, defaultValue string) string {
+	if value, ok := os.LookupEnv(name); ok && value != "" {
+		return value
+	}
+	return defaultValue
+}
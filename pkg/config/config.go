@@ -0,0 +1,213 @@
+// Package config loads the dapr-actor-gen.yaml file holding the settings of a generator run
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/shogotsuneto/dapr-actor-gen/pkg/generator"
+	"github.com/shogotsuneto/dapr-actor-gen/pkg/parser"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the config file discovered in the working directory
+const FileName = "dapr-actor-gen.yaml"
+
+// packageNamePattern matches the package names accepted for actor packages
+var packageNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Config is the content of a dapr-actor-gen.yaml file:
+//
+//	input: openapi.yaml
+//	output: ./generated
+//	module: github.com/acme/actors/generated
+//	packageSuffix: actor
+//	actors:
+//	  BankAccount:
+//	    package: bank
+//	typeMappings:
+//	  decimal: github.com/shopspring/decimal.Decimal
+//	generate:
+//	  impl: true
+//	  mock: true
+//	  validation: true
+//
+// Relative paths are resolved against the directory of the config file.
+type Config struct {
+	Input            Inputs            `yaml:"input"`            // OpenAPI spec file(s)
+	Output           string            `yaml:"output"`           // Base output directory
+	Module           string            `yaml:"module"`           // Go import path of the output directory
+	DetectModule     bool              `yaml:"detectModule"`     // Derive the import path from the go.mod enclosing the output directory
+	PackageSuffix    string            `yaml:"packageSuffix"`    // Appended to the lowercased actor type to form package names
+	Actors           map[string]Actor  `yaml:"actors"`           // Per-actor overrides keyed by actor type
+	TypeMappings     map[string]string `yaml:"typeMappings"`     // OpenAPI format -> Go type, as with -type-mapping
+	Generate         Generate          `yaml:"generate"`         // Optional artifacts to generate
+	OptionalPointers bool              `yaml:"optionalPointers"` // Generate optional and nullable fields as pointer types
+	SharedTypes      bool              `yaml:"sharedTypes"`      // Emit types used by several actors into a shared package
+	Templates        string            `yaml:"templates"`        // Directory with template overrides
+	Transport        string            `yaml:"transport"`        // Transport of the example application
+}
+
+// Inputs lists the spec files of a config; a single file may be given as a plain string
+type Inputs []string
+
+// UnmarshalYAML accepts a single path or a list of paths
+func (i *Inputs) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*i = Inputs{value.Value}
+		return nil
+	}
+	var paths []string
+	if err := value.Decode(&paths); err != nil {
+		return fmt.Errorf("line %d: input must be a path or a list of paths", value.Line)
+	}
+	*i = paths
+	return nil
+}
+
+// Actor holds the overrides of a single actor type
+type Actor struct {
+	Package string `yaml:"package"` // Package name (and output subdirectory) of the actor
+	Skip    bool   `yaml:"skip"`    // Do not generate code for the actor
+}
+
+// Generate selects the optional artifacts to generate
+type Generate struct {
+	Impl       bool `yaml:"impl"`       // Partial implementation stubs
+	MergeImpl  bool `yaml:"mergeImpl"`  // Merge stubs for new methods into an existing impl.go
+	Mock       bool `yaml:"mock"`       // Mocks of the actor and client interfaces
+	Example    bool `yaml:"example"`    // Example application
+	Validation bool `yaml:"validation"` // Validate() methods and request validation
+}
+
+// Find returns the path of the config file in dir, or "" if there is none
+func Find(dir string) (string, error) {
+	path := filepath.Join(dir, FileName)
+	_, err := os.Stat(path)
+	if err == nil {
+		return path, nil
+	}
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return "", fmt.Errorf("failed to look up %s: %v", path, err)
+}
+
+// Load reads and validates a config file. Relative paths in the config are resolved against its directory.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %v", err)
+	}
+
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+	cfg.resolvePaths(filepath.Dir(path))
+	return cfg, nil
+}
+
+// Parse decodes and validates the content of a config file. Unknown keys are rejected.
+func Parse(data []byte) (*Config, error) {
+	var cfg Config
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate checks the values of the config
+func (c *Config) Validate() error {
+	for _, input := range c.Input {
+		if strings.TrimSpace(input) == "" {
+			return fmt.Errorf("input: spec paths must not be empty")
+		}
+	}
+	if c.Module != "" && c.DetectModule {
+		return fmt.Errorf("module and detectModule cannot be combined")
+	}
+	if strings.ContainsAny(c.Module, " \t\\") {
+		return fmt.Errorf("module: '%s' is not a valid Go import path", c.Module)
+	}
+	if c.PackageSuffix != "" && !packageNamePattern.MatchString("x"+c.PackageSuffix) {
+		return fmt.Errorf("packageSuffix: '%s' may only contain lowercase letters, digits and underscores", c.PackageSuffix)
+	}
+
+	if err := generator.ValidateTransport(c.Transport); err != nil {
+		return fmt.Errorf("transport: %v", err)
+	}
+
+	for _, actorType := range sortedKeys(c.Actors) {
+		packageName := c.Actors[actorType].Package
+		if packageName != "" && !packageNamePattern.MatchString(packageName) {
+			return fmt.Errorf("actors.%s.package: '%s' is not a valid package name (lowercase letters, digits and underscores, starting with a letter)", actorType, packageName)
+		}
+	}
+
+	for _, format := range sortedKeys(c.TypeMappings) {
+		if _, _, err := parser.ParseFormatMapping(format + "=" + c.TypeMappings[format]); err != nil {
+			return fmt.Errorf("typeMappings.%s: %v", format, err)
+		}
+	}
+	return nil
+}
+
+// FormatMappings returns the type mappings of the config keyed by format
+func (c *Config) FormatMappings() map[string]parser.FormatMapping {
+	mappings := make(map[string]parser.FormatMapping)
+	for format, goType := range c.TypeMappings {
+		// Mappings were checked by Validate
+		_, mapping, _ := parser.ParseFormatMapping(format + "=" + goType)
+		mappings[format] = mapping
+	}
+	return mappings
+}
+
+// PackageNames returns the package name overrides of the config keyed by actor type
+func (c *Config) PackageNames() map[string]string {
+	packageNames := make(map[string]string)
+	for actorType, actor := range c.Actors {
+		if actor.Package != "" {
+			packageNames[actorType] = actor.Package
+		}
+	}
+	return packageNames
+}
+
+// resolvePaths makes the relative paths of the config relative to dir
+func (c *Config) resolvePaths(dir string) {
+	resolve := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(dir, path)
+	}
+	for i, input := range c.Input {
+		c.Input[i] = resolve(input)
+	}
+	c.Output = resolve(c.Output)
+	c.Templates = resolve(c.Templates)
+}
+
+// sortedKeys returns the keys of a map in ascending order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
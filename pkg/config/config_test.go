@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cfg, err := Parse([]byte(`
+input: openapi.yaml
+output: ./generated
+module: github.com/acme/actors/generated
+packageSuffix: actor
+actors:
+  BankAccount:
+    package: bank
+  Legacy:
+    skip: true
+typeMappings:
+  decimal: github.com/shopspring/decimal.Decimal
+generate:
+  impl: true
+  validation: true
+optionalPointers: true
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	if !reflect.DeepEqual(cfg.Input, Inputs{"openapi.yaml"}) {
+		t.Errorf("Expected a single input, got %v", cfg.Input)
+	}
+	if !cfg.Generate.Impl || !cfg.Generate.Validation || cfg.Generate.Mock || !cfg.OptionalPointers {
+		t.Errorf("Unexpected generate settings: %+v, optionalPointers=%v", cfg.Generate, cfg.OptionalPointers)
+	}
+	if packageNames := cfg.PackageNames(); !reflect.DeepEqual(packageNames, map[string]string{"BankAccount": "bank"}) {
+		t.Errorf("Unexpected package names: %v", packageNames)
+	}
+	if mapping := cfg.FormatMappings()["decimal"]; mapping.Type != "decimal.Decimal" || mapping.Import != "github.com/shopspring/decimal" {
+		t.Errorf("Unexpected decimal mapping: %+v", mapping)
+	}
+}
+
+func TestParseInputList(t *testing.T) {
+	cfg, err := Parse([]byte("input:\n  - counter.yaml\n  - bank.yaml\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Input, Inputs{"counter.yaml", "bank.yaml"}) {
+		t.Errorf("Expected two inputs, got %v", cfg.Input)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		expected string
+	}{
+		{"unknown key", "outptu: ./generated\n", "field outptu not found"},
+		{"wrong type", "generate:\n  impl: maybe\n", "cannot unmarshal"},
+		{"input map", "input:\n  spec: openapi.yaml\n", "input must be a path or a list of paths"},
+		{"package name", "actors:\n  Counter:\n    package: Counter-Actor\n", "actors.Counter.package"},
+		{"package suffix", "packageSuffix: Actor\n", "packageSuffix"},
+		{"type mapping", "typeMappings:\n  decimal: ''\n", "typeMappings.decimal"},
+		{"module", "module: my module\n", "not a valid Go import path"},
+		{"transport", "transport: grpc\n", "does not support hosting actors"},
+		{"detect module", "module: github.com/acme/actors\ndetectModule: true\n", "module and detectModule cannot be combined"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse([]byte(test.config))
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("Expected an error containing '%s', got: %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestLoadResolvesPaths(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte("input: specs/openapi.yaml\noutput: generated\ntemplates: /opt/templates\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	path, err := Find(dir)
+	if err != nil || path != filepath.Join(dir, FileName) {
+		t.Fatalf("Expected to find the config file in %s, got '%s' (%v)", dir, path, err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Paths are relative to the config file; absolute paths are kept
+	if cfg.Input[0] != filepath.Join(dir, "specs", "openapi.yaml") || cfg.Output != filepath.Join(dir, "generated") {
+		t.Errorf("Expected paths relative to %s, got input %v and output %s", dir, cfg.Input, cfg.Output)
+	}
+	if cfg.Templates != "/opt/templates" {
+		t.Errorf("Expected the absolute templates path to be kept, got %s", cfg.Templates)
+	}
+
+	if path, err := Find(t.TempDir()); err != nil || path != "" {
+		t.Errorf("Expected no config file in an empty directory, got '%s' (%v)", path, err)
+	}
+}
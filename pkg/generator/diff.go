@@ -0,0 +1,200 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change in a unified diff
+const diffContext = 3
+
+// maxDiffEdits bounds the edit distance a diff is computed for; the trace of the Myers algorithm grows
+// quadratically with it. Files differing in more lines are reported without a diff.
+const maxDiffEdits = 2000
+
+// diffLine is a single line of an edit script: ' ' unchanged, '-' removed, '+' added
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// unifiedDiff renders a unified diff turning from into to; it returns "" when both are equal
+func unifiedDiff(fromName, toName string, from, to []byte) string {
+	if string(from) == string(to) {
+		return ""
+	}
+
+	a := splitLines(string(from))
+	b := splitLines(string(to))
+	edits, ok := diffLines(a, b)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+	if !ok {
+		fmt.Fprintf(&sb, "\\ Files differ in more than %d lines (%d lines, %d lines generated); no diff is shown\n", maxDiffEdits, len(a), len(b))
+		return sb.String()
+	}
+
+	// Line numbers in a and b before each edit
+	aLine := make([]int, len(edits)+1)
+	bLine := make([]int, len(edits)+1)
+	for i, edit := range edits {
+		aLine[i+1], bLine[i+1] = aLine[i], bLine[i]
+		if edit.op != '+' {
+			aLine[i+1]++
+		}
+		if edit.op != '-' {
+			bLine[i+1]++
+		}
+	}
+
+	hunks := 0
+	for i := 0; i < len(edits); {
+		if edits[i].op == ' ' {
+			i++
+			continue
+		}
+
+		// Extend the hunk while the gap between changes is small enough to share context
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+		last := i
+		for j := i; j < len(edits); j++ {
+			if edits[j].op != ' ' {
+				last = j
+			} else if j-last > 2*diffContext {
+				break
+			}
+		}
+		end := last + diffContext + 1
+		if end > len(edits) {
+			end = len(edits)
+		}
+
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n",
+			hunkRange(aLine[start], aLine[end]-aLine[start]),
+			hunkRange(bLine[start], bLine[end]-bLine[start]))
+		for _, edit := range edits[start:end] {
+			sb.WriteByte(edit.op)
+			sb.WriteString(edit.text)
+			sb.WriteByte('\n')
+		}
+		hunks++
+		i = end
+	}
+
+	// Contents that differ only in the final newline produce no line changes
+	if hunks == 0 {
+		sb.WriteString("\\ Files differ only in the trailing newline\n")
+	}
+
+	return sb.String()
+}
+
+// hunkRange formats the start,count part of a hunk header
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// splitLines splits text into lines without their line terminators
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines computes a shortest edit script between a and b using the Myers algorithm. It reports false
+// when the script needs more than maxDiffEdits insertions and deletions.
+func diffLines(a, b []string) ([]diffLine, bool) {
+	n, m := len(a), len(b)
+
+	// A missing or emptied file is a single insertion or deletion
+	if n == 0 || m == 0 {
+		edits := make([]diffLine, 0, n+m)
+		for _, line := range a {
+			edits = append(edits, diffLine{op: '-', text: line})
+		}
+		for _, line := range b {
+			edits = append(edits, diffLine{op: '+', text: line})
+		}
+		return edits, true
+	}
+
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+
+	// trace[d] holds the furthest reaching x of the diagonals -d-1..d+1 before step d
+	var trace [][]int
+	found := false
+	for d := 0; d <= max && !found; d++ {
+		if d > maxDiffEdits {
+			return nil, false
+		}
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+
+	// Walk the trace backwards to recover the edit script
+	var edits []diffLine
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		base := d + 1 // index of diagonal 0 in trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[base+k-1] < v[base+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[base+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			edits = append(edits, diffLine{op: ' ', text: a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, diffLine{op: '+', text: b[y-1]})
+				y--
+			} else {
+				edits = append(edits, diffLine{op: '-', text: a[x-1]})
+				x--
+			}
+		}
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits, true
+}
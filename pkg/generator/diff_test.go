@@ -0,0 +1,43 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	from := "package counter\n\nfunc A() {}\n\nfunc B() {}\n"
+	to := "package counter\n\nfunc A() {}\n\nfunc C() {}\n"
+
+	expected := `--- api.go
++++ api.go (generated)
+@@ -2,4 +2,4 @@
+ 
+ func A() {}
+ 
+-func B() {}
++func C() {}
+`
+	if diff := unifiedDiff("api.go", "api.go (generated)", []byte(from), []byte(to)); diff != expected {
+		t.Errorf("Unexpected diff.\nExpected:\n%s\nGot:\n%s", expected, diff)
+	}
+
+	// A missing file is added as a whole
+	diff := unifiedDiff("api.go", "api.go (generated)", nil, []byte(to))
+	if !strings.Contains(diff, "@@ -0,0 +1,5 @@\n+package counter\n") {
+		t.Errorf("Expected the whole file to be added. Got:\n%s", diff)
+	}
+}
+
+func TestUnifiedDiffTooManyChanges(t *testing.T) {
+	var from, to strings.Builder
+	for i := 0; i < maxDiffEdits; i++ {
+		from.WriteString("a\n")
+		to.WriteString("b\n")
+	}
+
+	diff := unifiedDiff("types.go", "types.go (generated)", []byte(from.String()), []byte(to.String()))
+	if !strings.Contains(diff, "no diff is shown") {
+		t.Errorf("Expected the diff to be omitted. Got:\n%s", diff)
+	}
+}
@@ -11,6 +11,12 @@ func TestEmbeddedTemplates(t *testing.T) {
 		"actor_types.tmpl",
 		"interface.tmpl",
 		"factory.tmpl",
+		"client.tmpl",
+		"mock.tmpl",
+		"state.tmpl",
+		"reminders.tmpl",
+		"errors.tmpl",
+		"config.tmpl",
 	}
 
 	for _, templateName := range templateNames {
@@ -41,6 +47,12 @@ func TestEmbeddedTemplateContent(t *testing.T) {
 		{"interface.tmpl", "actor.ServerContext"},
 		{"factory.tmpl", "NewActorFactory"},
 		{"actor_types.tmpl", "package test"},
+		{"client.tmpl", "NewTestActorClient"},
+		{"mock.tmpl", "MockTestActor"},
+		{"state.tmpl", "NewState"},
+		{"errors.tmpl", "func DecodeError(err error) error"},
+		{"reminders.tmpl", "Names of the reminders and timers of the TestActor actor"},
+		{"config.tmpl", "runtime settings of the TestActor actor"},
 	}
 
 	for _, test := range tests {
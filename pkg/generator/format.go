@@ -0,0 +1,187 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// standardImports maps package names to the standard library packages that are added when referenced but not imported
+var standardImports = map[string]string{
+	"bytes":   "bytes",
+	"context": "context",
+	"errors":  "errors",
+	"fmt":     "fmt",
+	"http":    "net/http",
+	"json":    "encoding/json",
+	"log":     "log",
+	"os":      "os",
+	"regexp":  "regexp",
+	"signal":  "os/signal",
+	"sort":    "sort",
+	"strconv": "strconv",
+	"strings": "strings",
+	"sync":    "sync",
+	"syscall": "syscall",
+	"time":    "time",
+	"utf8":    "unicode/utf8",
+}
+
+// formatGoSource formats rendered Go source like gofmt and goimports: unused imports are removed,
+// missing standard library imports and imports of knownImports (package qualifier -> import path) are added
+// and imports are grouped into standard library, third-party and local packages (import paths under localPrefix).
+func formatGoSource(src []byte, localPrefix string, knownImports map[string]string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	// Package qualifiers referenced in the file (identifiers that do not resolve to local declarations)
+	used := make(map[string]bool)
+	ast.Inspect(file, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok && ident.Obj == nil {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	type importLine struct {
+		path string
+		line string
+	}
+	var imports []importLine
+	imported := make(map[string]bool)
+	changed := false
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		name := ImportPathToAssumedName(importPath)
+		line := spec.Path.Value
+		if spec.Name != nil {
+			name = spec.Name.Name
+			line = spec.Name.Name + " " + spec.Path.Value
+		}
+		imported[name] = true
+
+		// Blank and dot imports are kept for their side effects
+		if name != "_" && name != "." && !used[name] {
+			changed = true
+			continue
+		}
+		imports = append(imports, importLine{path: importPath, line: line})
+	}
+	for name := range used {
+		if imported[name] {
+			continue
+		}
+		importPath, ok := standardImports[name]
+		if !ok {
+			importPath, ok = knownImports[name]
+		}
+		if ok {
+			imports = append(imports, importLine{path: importPath, line: strconv.Quote(importPath)})
+			changed = true
+		}
+	}
+
+	if changed || len(file.Imports) > 0 {
+		sort.Slice(imports, func(i, j int) bool {
+			return imports[i].path < imports[j].path
+		})
+		var standard, other, local []string
+		for _, imp := range imports {
+			switch {
+			case imp.path == localPrefix || strings.HasPrefix(imp.path, localPrefix+"/"):
+				local = append(local, "\t"+imp.line)
+			case isStandardImport(imp.path):
+				standard = append(standard, "\t"+imp.line)
+			default:
+				other = append(other, "\t"+imp.line)
+			}
+		}
+		var groups []string
+		for _, group := range [][]string{standard, other, local} {
+			if len(group) > 0 {
+				groups = append(groups, strings.Join(group, "\n"))
+			}
+		}
+		block := ""
+		if len(groups) > 0 {
+			block = "import (\n" + strings.Join(groups, "\n\n") + "\n)"
+		}
+		src = replaceImportDecls(src, fset, file, block)
+	}
+
+	return format.Source(src)
+}
+
+// replaceImportDecls replaces all import declarations of a parsed file's source with block
+func replaceImportDecls(src []byte, fset *token.FileSet, file *ast.File, block string) []byte {
+	var start, end int
+	found := false
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT {
+			continue
+		}
+		if !found {
+			start = fset.Position(genDecl.Pos()).Offset
+			found = true
+		}
+		end = fset.Position(genDecl.End()).Offset
+	}
+
+	if !found {
+		if block == "" {
+			return src
+		}
+		offset := fset.Position(file.Name.End()).Offset
+		start, end = offset, offset
+		block = "\n\n" + block
+	}
+
+	result := make([]byte, 0, len(src)+len(block))
+	result = append(result, src[:start]...)
+	result = append(result, block...)
+	return append(result, src[end:]...)
+}
+
+// isStandardImport reports whether an import path belongs to the standard library
+func isStandardImport(importPath string) bool {
+	first := strings.SplitN(importPath, "/", 2)[0]
+	return !strings.Contains(first, ".")
+}
+
+// ImportPathToAssumedName returns the package name assumed for an import path, following goimports:
+// major version suffixes and "go-" prefixes are skipped and the name ends at the first non-identifier rune.
+func ImportPathToAssumedName(importPath string) string {
+	base := path.Base(importPath)
+	if strings.HasPrefix(base, "v") {
+		if _, err := strconv.Atoi(base[1:]); err == nil {
+			if dir := path.Dir(importPath); dir != "." {
+				base = path.Base(dir)
+			}
+		}
+	}
+	base = strings.TrimPrefix(base, "go-")
+	if i := strings.IndexFunc(base, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}); i >= 0 {
+		base = base[:i]
+	}
+	return base
+}
+
+// formatError describes rendered source that is not valid Go
+func formatError(fileName, templateName, owner string, err error) error {
+	return fmt.Errorf("%s rendered from template %s for %s is not valid Go: %v", fileName, templateName, owner, err)
+}
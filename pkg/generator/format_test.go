@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatGoSource(t *testing.T) {
+	src := `package counter
+import (
+	"errors"
+	"example-dapr-actors/types"
+	"github.com/dapr/go-sdk/actor"
+)
+
+
+type Counter struct {
+	actor.ServerImplBaseCtx
+	State   types.State
+	Owner uuid.UUID
+}
+func (c *Counter) Describe() string { return fmt.Sprintf("%s", c.ID()) }
+`
+
+	formatted, err := formatGoSource([]byte(src), "example-dapr-actors", map[string]string{"uuid": "github.com/google/uuid"})
+	if err != nil {
+		t.Fatalf("Failed to format source: %v", err)
+	}
+
+	expected := `package counter
+
+import (
+	"fmt"
+
+	"github.com/dapr/go-sdk/actor"
+	"github.com/google/uuid"
+
+	"example-dapr-actors/types"
+)
+
+type Counter struct {
+	actor.ServerImplBaseCtx
+	State types.State
+	Owner uuid.UUID
+}
+
+func (c *Counter) Describe() string { return fmt.Sprintf("%s", c.ID()) }
+`
+	if string(formatted) != expected {
+		t.Errorf("Unexpected formatted source.\nExpected:\n%s\nGot:\n%s", expected, formatted)
+	}
+}
+
+func TestFormatGoSourceInvalid(t *testing.T) {
+	_, err := formatGoSource([]byte("package counter\n\nfunc broken( {\n"), "example-dapr-actors", nil)
+	if err == nil {
+		t.Fatal("Expected an error for invalid source")
+	}
+
+	err = formatError("types.go", "actor_types.tmpl", "Counter", err)
+	for _, expected := range []string{"types.go", "actor_types.tmpl", "Counter"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected '%s' in error message, got: %v", expected, err)
+		}
+	}
+}
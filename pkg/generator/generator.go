@@ -1,9 +1,12 @@
 package generator
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -12,22 +15,116 @@ type Generator struct{}
 
 // GenerateActorPackages generates actor-specific packages from the intermediate model
 func (g *Generator) GenerateActorPackages(model *GenerationModel, baseOutputDir string, options GenerationOptions) error {
+	files, err := g.RenderActorPackages(model, baseOutputDir, options)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		dir := filepath.Dir(file.Path)
+		err := os.MkdirAll(dir, 0755)
+		if err != nil {
+			return fmt.Errorf("failed to create output directory %s: %v", dir, err)
+		}
+		err = os.WriteFile(file.Path, file.Content, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %v", file.Path, err)
+		}
+	}
+
+	printGeneratedFiles(files, baseOutputDir)
+	return nil
+}
+
+// RenderActorPackages renders every file GenerateActorPackages would write, without touching the disk.
+// File paths are joined with baseOutputDir.
+func (g *Generator) RenderActorPackages(model *GenerationModel, baseOutputDir string, options GenerationOptions) ([]GeneratedFile, error) {
 	if len(model.Actors) == 0 {
-		return fmt.Errorf("no actors found in the model")
+		return nil, fmt.Errorf("no actors found in the model")
 	}
 
-	// Generate package for each actor type
+	if err := checkTemplatesDir(options); err != nil {
+		return nil, err
+	}
+	actorTemplates, err := userTemplates(options, actorTemplatesDir)
+	if err != nil {
+		return nil, err
+	}
+	modelTemplates, err := userTemplates(options, modelTemplatesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []GeneratedFile
+
+	// Go sources are formatted and their imports fixed before they are returned
+	add := func(filePath, templateName, owner string, content []byte) error {
+		for _, file := range files {
+			if file.Path == filePath {
+				return fmt.Errorf("template %s for %s renders %s, which is already generated", templateName, owner, filePath)
+			}
+		}
+		if strings.HasSuffix(filePath, ".go") {
+			formatted, err := formatGoSource(content, modulePath(options), model.Imports)
+			if err != nil {
+				return formatError(filepath.Base(filePath), templateName, owner, err)
+			}
+			content = formatted
+		}
+		files = append(files, GeneratedFile{Path: filePath, Content: content})
+		return nil
+	}
+
+	// addUserTemplates renders additional user templates into dir
+	addUserTemplates := func(paths []string, dir, owner string, data interface{}) error {
+		for _, path := range paths {
+			content, err := renderUserTemplate(path, data)
+			if err != nil {
+				return fmt.Errorf("failed to render user template for %s: %v", owner, err)
+			}
+			templateName := filepath.Join(filepath.Base(filepath.Dir(path)), filepath.Base(path))
+			if err := add(filepath.Join(dir, strings.TrimSuffix(filepath.Base(path), ".tmpl")), templateName, owner, content); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Every actor needs a package of its own
+	packageOwners := make(map[string]string)
 	for _, actor := range model.Actors {
-		// Create actor-specific package name and directory using actorType as is
-		packageName := strings.ToLower(actor.ActorType)
+		packageName := actorPackageName(actor.ActorType, options)
+		if owner, exists := packageOwners[packageName]; exists {
+			return nil, fmt.Errorf("actors %s and %s would both be generated into package %s", owner, actor.ActorType, packageName)
+		}
+		packageOwners[packageName] = actor.ActorType
+	}
 
-		outputDir := filepath.Join(baseOutputDir, packageName)
+	// Optionally move types used by several actors into a shared package
+	var sharedTypes TypeDefinitions
+	if options.SharedTypes {
+		if _, exists := packageOwners[sharedTypesPackage]; exists {
+			return nil, fmt.Errorf("actor package %s conflicts with the shared types package", sharedTypesPackage)
+		}
 
-		// Create output directory
-		err := os.MkdirAll(outputDir, 0755)
-		if err != nil {
-			return fmt.Errorf("failed to create output directory %s: %v", outputDir, err)
+		sharedTypes = splitSharedTypes(model.Actors)
+		if len(sharedTypeNames(sharedTypes)) > 0 {
+			content, err := g.generateTypesFile(sharedTypesPackage, sharedTypes, TypeDefinitions{}, model.Imports, options)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate shared types: %v", err)
+			}
+			if err := add(filepath.Join(baseOutputDir, sharedTypesPackage, "types.go"), "actor_types.tmpl", "the shared types package", content); err != nil {
+				return nil, err
+			}
 		}
+	}
+
+	// Generate package for each actor type
+	for _, actor := range model.Actors {
+		// Create actor-specific package name and directory from the actor type
+		packageName := actorPackageName(actor.ActorType, options)
+
+		outputDir := filepath.Join(baseOutputDir, packageName)
 
 		// Get actor-specific types directly from the actor
 		actorSpecificTypes := actor.Types
@@ -38,252 +135,787 @@ func (g *Generator) GenerateActorPackages(model *GenerationModel, baseOutputDir
 			PackageName:    packageName,
 			Types:          actorSpecificTypes,
 			ActorInterface: actor,
+			Imports:        model.Imports,
+		}
+		if options.SharedTypes {
+			actorModel.Types, actorModel.SharedTypes = partitionTypes(actor.Types, sharedTypes)
 		}
 
 		// Generate types for this actor
-		err = g.generateActorTypes(&actorModel, outputDir)
+		content, err := g.generateActorTypes(&actorModel, options)
 		if err != nil {
-			return fmt.Errorf("failed to generate types for %s: %v", actor.ActorType, err)
+			return nil, fmt.Errorf("failed to generate types for %s: %v", actor.ActorType, err)
+		}
+		if err := add(filepath.Join(outputDir, "types.go"), "actor_types.tmpl", actor.ActorType, content); err != nil {
+			return nil, err
 		}
 
 		// Generate interface for this actor
-		err = g.generateActorInterface(&actorModel, outputDir)
+		content, err = g.generateActorInterface(&actorModel, options)
 		if err != nil {
-			return fmt.Errorf("failed to generate interface for %s: %v", actor.ActorType, err)
+			return nil, fmt.Errorf("failed to generate interface for %s: %v", actor.ActorType, err)
+		}
+		if err := add(filepath.Join(outputDir, "api.go"), "interface.tmpl", actor.ActorType, content); err != nil {
+			return nil, err
 		}
 
 		// Generate factory for this actor
-		err = g.generateActorFactory(&actorModel, outputDir)
+		content, err = g.generateActorFactory(&actorModel, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate factory for %s: %v", actor.ActorType, err)
+		}
+		if err := add(filepath.Join(outputDir, "factory.go"), "factory.tmpl", actor.ActorType, content); err != nil {
+			return nil, err
+		}
+
+		// Generate typed client proxy for this actor
+		content, err = g.generateActorClient(&actorModel, options)
 		if err != nil {
-			return fmt.Errorf("failed to generate factory for %s: %v", actor.ActorType, err)
+			return nil, fmt.Errorf("failed to generate client for %s: %v", actor.ActorType, err)
+		}
+		if err := add(filepath.Join(outputDir, "client.go"), "client.tmpl", actor.ActorType, content); err != nil {
+			return nil, err
+		}
+
+		// Generate typed state accessors when the spec declares the actor's state
+		if len(actor.State) > 0 {
+			content, err = g.generateActorState(&actorModel, options)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate state accessors for %s: %v", actor.ActorType, err)
+			}
+			if err := add(filepath.Join(outputDir, "state.go"), "state.tmpl", actor.ActorType, content); err != nil {
+				return nil, err
+			}
+		}
+
+		// Generate the runtime configuration when the spec declares it
+		if actor.Config != nil {
+			content, err = g.generateActorConfig(&actorModel, options)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate runtime config for %s: %v", actor.ActorType, err)
+			}
+			if err := add(filepath.Join(outputDir, "config.go"), "config.tmpl", actor.ActorType, content); err != nil {
+				return nil, err
+			}
+		}
+
+		// Generate error types for the documented error responses
+		if len(actor.ErrorTypes()) > 0 {
+			content, err = g.generateActorErrors(&actorModel, options)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate error types for %s: %v", actor.ActorType, err)
+			}
+			if err := add(filepath.Join(outputDir, "errors.go"), "errors.tmpl", actor.ActorType, content); err != nil {
+				return nil, err
+			}
+		}
+
+		// Generate reminder dispatch and registration helpers when the spec declares reminders or timers
+		if len(actor.Callbacks()) > 0 {
+			content, err = g.generateActorReminders(&actorModel, options)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate reminders for %s: %v", actor.ActorType, err)
+			}
+			if err := add(filepath.Join(outputDir, "reminders.go"), "reminders.tmpl", actor.ActorType, content); err != nil {
+				return nil, err
+			}
+		}
+
+		// Optionally generate a test double for the actor and its client
+		if options.GenerateMock {
+			content, err = g.generateActorMock(&actorModel, options)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate mock for %s: %v", actor.ActorType, err)
+			}
+			if err := add(filepath.Join(outputDir, "mock.go"), "mock.tmpl", actor.ActorType, content); err != nil {
+				return nil, err
+			}
 		}
 
 		// Optionally generate partial implementation
-		if options.GenerateImpl {
-			err = g.generatePartialImplementation(&actorModel, outputDir)
+		if options.GenerateImpl || options.MergeImpl {
+			implPath := filepath.Join(outputDir, "impl.go")
+			content, warnings, err := g.generatePartialImplementation(&actorModel, implPath, options)
 			if err != nil {
-				return fmt.Errorf("failed to generate partial implementation for %s: %v", actor.ActorType, err)
+				return nil, fmt.Errorf("failed to generate partial implementation for %s: %v", actor.ActorType, err)
+			}
+			if err := add(implPath, "partial_impl.tmpl", actor.ActorType, content); err != nil {
+				return nil, err
 			}
+			files[len(files)-1].Warnings = warnings
 		}
 
-		fmt.Printf("Generated actor package: %s\n", outputDir)
-		fmt.Printf("  %s/types.go\n", outputDir)
-		fmt.Printf("  %s/api.go\n", outputDir)
-		fmt.Printf("  %s/factory.go\n", outputDir)
-		if options.GenerateImpl {
-			fmt.Printf("  %s/impl.go\n", outputDir)
+		// Render the additional user templates of every actor
+		if err := addUserTemplates(actorTemplates, outputDir, actor.ActorType, &actorModel); err != nil {
+			return nil, err
 		}
 	}
 
 	// Optionally generate example application
 	if options.GenerateExample {
-		err := g.generateExampleApplication(model, baseOutputDir)
+		if err := ValidateTransport(options.Transport); err != nil {
+			return nil, fmt.Errorf("failed to generate example application: %v", err)
+		}
+		content, err := g.generateExampleMain(model, options)
 		if err != nil {
-			return fmt.Errorf("failed to generate example application: %v", err)
+			return nil, fmt.Errorf("failed to generate example application: failed to generate example main.go: %v", err)
+		}
+		if err := add(filepath.Join(baseOutputDir, "main.go"), "example_main.tmpl", "the example application", content); err != nil {
+			return nil, err
+		}
+
+		// Inside an existing module a nested go.mod would hide the generated packages from it
+		if !options.InModule {
+			content, err = g.generateExampleGoMod(model, options)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate example application: failed to generate example go.mod: %v", err)
+			}
+			if err := add(filepath.Join(baseOutputDir, "go.mod"), "example_gomod.tmpl", "the example application", content); err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	return nil
+	// Render the additional user templates of the model
+	if err := addUserTemplates(modelTemplates, baseOutputDir, "the model", model); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// CheckActorPackages renders the actor packages in memory and compares them with the files in baseOutputDir.
+// A unified diff is written to w for every file that is missing or differs; the paths of those files are returned.
+func (g *Generator) CheckActorPackages(model *GenerationModel, baseOutputDir string, options GenerationOptions, w io.Writer) ([]string, error) {
+	files, err := g.RenderActorPackages(model, baseOutputDir, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var outdated []string
+	rendered := make(map[string]bool)
+	for _, file := range files {
+		rendered[filepath.Clean(file.Path)] = true
+		existing, err := os.ReadFile(file.Path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read %s: %v", file.Path, err)
+		}
+		if err == nil && bytes.Equal(existing, file.Content) {
+			continue
+		}
+
+		outdated = append(outdated, file.Path)
+		fmt.Fprint(w, unifiedDiff(file.Path, file.Path+" (generated)", existing, file.Content))
+	}
+
+	// Generated files that would no longer be generated, e.g. of an actor removed from the spec
+	extraneous, err := extraneousFiles(baseOutputDir, rendered)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range extraneous {
+		existing, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", path, err)
+		}
+		outdated = append(outdated, path)
+		fmt.Fprint(w, unifiedDiff(path, "/dev/null", existing, nil))
+	}
+
+	return outdated, nil
+}
+
+// generatedMarker identifies the Go files rendered from the built-in templates
+const generatedMarker = "// Code generated from OpenAPI specification. DO NOT EDIT manually."
+
+// extraneousFiles returns the generated Go files under baseOutputDir that are not in rendered
+func extraneousFiles(baseOutputDir string, rendered map[string]bool) ([]string, error) {
+	var extraneous []string
+	err := filepath.WalkDir(baseOutputDir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == baseOutputDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() || !strings.HasSuffix(path, ".go") || rendered[filepath.Clean(path)] {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if bytes.Contains(content, []byte(generatedMarker)) {
+			extraneous = append(extraneous, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look for extraneous generated files: %v", err)
+	}
+	return extraneous, nil
 }
 
-func (g *Generator) generateActorTypes(actorModel *ActorModel, outputDir string) error {
+func (g *Generator) generateActorTypes(actorModel *ActorModel, options GenerationOptions) ([]byte, error) {
+	return g.generateTypesFile(actorModel.PackageName, actorModel.Types, actorModel.SharedTypes, actorModel.Imports, options)
+}
+
+// generateTypesFile renders types.go for a package declaring the given types and re-exporting fromShared
+func (g *Generator) generateTypesFile(packageName string, types, fromShared TypeDefinitions, knownImports map[string]string, options GenerationOptions) ([]byte, error) {
 	// Load template from embedded filesystem
-	tmpl, err := getEmbeddedTemplate("actor_types.tmpl")
+	tmpl, err := loadTemplate("actor_types.tmpl", options)
 	if err != nil {
-		return fmt.Errorf("failed to parse actor types template: %v", err)
+		return nil, fmt.Errorf("failed to parse actor types template: %v", err)
 	}
 
 	// Process types directly from the actor model
 	processedTypes := TypeDefinitions{
-		Structs: make([]StructType, len(actorModel.Types.Structs)),
-		Aliases: make([]TypeAlias, len(actorModel.Types.Aliases)),
-		Enums:   make([]EnumType, len(actorModel.Types.Enums)),
+		Structs: make([]StructType, len(types.Structs)),
+		Aliases: make([]TypeAlias, len(types.Aliases)),
+		Enums:   make([]EnumType, len(types.Enums)),
+		Unions:  make([]UnionType, len(types.Unions)),
+	}
+	copy(processedTypes.Structs, types.Structs)
+	copy(processedTypes.Aliases, types.Aliases)
+	copy(processedTypes.Enums, types.Enums)
+	copy(processedTypes.Unions, types.Unions)
+
+	// Optionally render optional and nullable fields as pointers so "not set" differs from zero values
+	if options.OptionalPointers {
+		for i, structType := range processedTypes.Structs {
+			fields := make([]Field, len(structType.Fields))
+			copy(fields, structType.Fields)
+			for j := range fields {
+				if (fields[j].Optional || fields[j].Nullable) && !isNilableType(fields[j].Type) {
+					fields[j].Type = "*" + fields[j].Type
+				}
+			}
+			processedTypes.Structs[i].Fields = fields
+		}
+	}
+
+	imports := typeImports(processedTypes, knownImports)
+
+	// Types declared in the shared package are re-exported as aliases
+	var shared *SharedTypesRef
+	if len(sharedTypeNames(fromShared)) > 0 {
+		shared = sharedTypesRef(fromShared)
+		imports = mergeImports(imports, []string{modulePath(options) + "/" + sharedTypesPackage})
+	}
+
+	// Optionally render Validate() methods from the schema constraints
+	var validation *ValidationModel
+	if options.GenerateValidation {
+		validation, err = buildValidation(processedTypes, fromShared)
+		if err != nil {
+			return nil, err
+		}
+		imports = mergeImports(imports, validation.Imports)
 	}
-	copy(processedTypes.Structs, actorModel.Types.Structs)
-	copy(processedTypes.Aliases, actorModel.Types.Aliases)
-	copy(processedTypes.Enums, actorModel.Types.Enums)
 
 	// Generate types file
 	data := struct {
 		PackageName string
+		Imports     []string
+		Shared      *SharedTypesRef
 		Types       TypeDefinitions
+		Validation  *ValidationModel
 	}{
-		PackageName: actorModel.PackageName,
+		PackageName: packageName,
+		Imports:     imports,
+		Shared:      shared,
 		Types:       processedTypes,
+		Validation:  validation,
 	}
 
-	typesFile, err := os.Create(fmt.Sprintf("%s/types.go", outputDir))
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
 	if err != nil {
-		return fmt.Errorf("failed to create types file: %v", err)
+		return nil, fmt.Errorf("failed to execute actor types template: %v", err)
 	}
-	defer typesFile.Close()
 
-	err = tmpl.Execute(typesFile, data)
+	return buf.Bytes(), nil
+}
+
+func (g *Generator) generateActorInterface(actorModel *ActorModel, options GenerationOptions) ([]byte, error) {
+	// Load template from embedded filesystem
+	tmpl, err := loadTemplate("interface.tmpl", options)
 	if err != nil {
-		return fmt.Errorf("failed to execute actor types template: %v", err)
+		return nil, fmt.Errorf("failed to parse interface template: %v", err)
 	}
 
-	return nil
+	// Generate interface file for this actor
+	data := SingleActorTemplateData{
+		PackageName: actorModel.PackageName,
+		Actor:       actorModel.ActorInterface,
+	}
+
+	// Use api.go as filename instead of generated.go for better clarity
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute interface template: %v", err)
+	}
+
+	return buf.Bytes(), nil
 }
 
-func (g *Generator) generateActorInterface(actorModel *ActorModel, outputDir string) error {
+func (g *Generator) generateActorFactory(actorModel *ActorModel, options GenerationOptions) ([]byte, error) {
 	// Load template from embedded filesystem
-	tmpl, err := getEmbeddedTemplate("interface.tmpl")
+	tmpl, err := loadTemplate("factory.tmpl", options)
 	if err != nil {
-		return fmt.Errorf("failed to parse interface template: %v", err)
+		return nil, fmt.Errorf("failed to parse factory template: %v", err)
 	}
 
-	// Generate interface file for this actor
+	// Methods whose request type has a generated Validate method are wrapped with validation
+	var validatedMethods []Method
+	if options.GenerateValidation {
+		validated := validatedTypes(actorModel.Types)
+		for name := range validatedTypes(actorModel.SharedTypes) {
+			validated[name] = true
+		}
+		for _, method := range actorModel.ActorInterface.Methods {
+			if method.HasRequest && validated[method.RequestType] {
+				validatedMethods = append(validatedMethods, method)
+			}
+		}
+	}
+
+	// Generate factory file for this actor
+	data := struct {
+		PackageName      string
+		Actor            ActorInterface
+		ValidatedMethods []Method
+	}{
+		PackageName:      actorModel.PackageName,
+		Actor:            actorModel.ActorInterface,
+		ValidatedMethods: validatedMethods,
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute factory template: %v", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (g *Generator) generateActorClient(actorModel *ActorModel, options GenerationOptions) ([]byte, error) {
+	// Load template from embedded filesystem
+	tmpl, err := loadTemplate("client.tmpl", options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client template: %v", err)
+	}
+
+	// Generate client file for this actor
 	data := SingleActorTemplateData{
 		PackageName: actorModel.PackageName,
 		Actor:       actorModel.ActorInterface,
 	}
 
-	// Use api.go as filename instead of generated.go for better clarity
-	interfaceFile, err := os.Create(filepath.Join(outputDir, "api.go"))
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
 	if err != nil {
-		return fmt.Errorf("failed to create interface file: %v", err)
+		return nil, fmt.Errorf("failed to execute client template: %v", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// generateActorState renders the typed State accessors for the state keys declared for an actor
+func (g *Generator) generateActorState(actorModel *ActorModel, options GenerationOptions) ([]byte, error) {
+	// The accessor type is named State, so it must not collide with a generated type
+	names := sharedTypeNames(actorModel.Types)
+	for name := range sharedTypeNames(actorModel.SharedTypes) {
+		names[name] = true
+	}
+	if names["State"] {
+		return nil, fmt.Errorf("schema State conflicts with the generated State accessors")
 	}
-	defer interfaceFile.Close()
 
-	err = tmpl.Execute(interfaceFile, data)
+	// Load template from embedded filesystem
+	tmpl, err := loadTemplate("state.tmpl", options)
 	if err != nil {
-		return fmt.Errorf("failed to execute interface template: %v", err)
+		return nil, fmt.Errorf("failed to parse state template: %v", err)
 	}
 
-	return nil
+	// Generate state file for this actor
+	data := SingleActorTemplateData{
+		PackageName: actorModel.PackageName,
+		Actor:       actorModel.ActorInterface,
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute state template: %v", err)
+	}
+
+	return buf.Bytes(), nil
 }
 
-func (g *Generator) generateActorFactory(actorModel *ActorModel, outputDir string) error {
+func (g *Generator) generateActorConfig(actorModel *ActorModel, options GenerationOptions) ([]byte, error) {
+	// The configuration is exposed as RuntimeConfig, Config and Options, which must not collide with generated types
+	names := sharedTypeNames(actorModel.Types)
+	for name := range sharedTypeNames(actorModel.SharedTypes) {
+		names[name] = true
+	}
+	for _, name := range []string{"RuntimeConfig", "Config", "Options"} {
+		if names[name] {
+			return nil, fmt.Errorf("schema %s conflicts with the generated runtime configuration", name)
+		}
+	}
+
 	// Load template from embedded filesystem
-	tmpl, err := getEmbeddedTemplate("factory.tmpl")
+	tmpl, err := loadTemplate("config.tmpl", options)
 	if err != nil {
-		return fmt.Errorf("failed to parse factory template: %v", err)
+		return nil, fmt.Errorf("failed to parse config template: %v", err)
 	}
 
-	// Generate factory file for this actor
+	// Generate config file for this actor
 	data := SingleActorTemplateData{
 		PackageName: actorModel.PackageName,
 		Actor:       actorModel.ActorInterface,
 	}
 
-	factoryFile, err := os.Create(filepath.Join(outputDir, "factory.go"))
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
 	if err != nil {
-		return fmt.Errorf("failed to create factory file: %v", err)
+		return nil, fmt.Errorf("failed to execute config template: %v", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// generateActorErrors renders the error types wrapping the error response bodies of an actor's methods
+func (g *Generator) generateActorErrors(actorModel *ActorModel, options GenerationOptions) ([]byte, error) {
+	errorTypes := actorModel.ActorInterface.ErrorTypes()
+
+	// Error types are declared next to the schema types, so their names must not collide
+	names := sharedTypeNames(actorModel.Types)
+	for name := range sharedTypeNames(actorModel.SharedTypes) {
+		names[name] = true
+	}
+	for _, errorType := range errorTypes {
+		if names[errorType.Name] {
+			return nil, fmt.Errorf("schema %s conflicts with the error type generated for %s responses", errorType.Name, errorType.BodyType)
+		}
 	}
-	defer factoryFile.Close()
 
-	err = tmpl.Execute(factoryFile, data)
+	// Load template from embedded filesystem
+	tmpl, err := loadTemplate("errors.tmpl", options)
 	if err != nil {
-		return fmt.Errorf("failed to execute factory template: %v", err)
+		return nil, fmt.Errorf("failed to parse errors template: %v", err)
 	}
 
-	return nil
+	// Generate errors file for this actor
+	data := struct {
+		PackageName string
+		ErrorTypes  []ErrorType
+	}{
+		PackageName: actorModel.PackageName,
+		ErrorTypes:  errorTypes,
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute errors template: %v", err)
+	}
+
+	return buf.Bytes(), nil
 }
 
-func (g *Generator) generatePartialImplementation(actorModel *ActorModel, outputDir string) error {
+// generateActorReminders renders the typed reminder dispatch and the reminder and timer registration helpers
+func (g *Generator) generateActorReminders(actorModel *ActorModel, options GenerationOptions) ([]byte, error) {
 	// Load template from embedded filesystem
-	tmpl, err := getEmbeddedTemplate("partial_impl.tmpl")
+	tmpl, err := loadTemplate("reminders.tmpl", options)
 	if err != nil {
-		return fmt.Errorf("failed to parse partial implementation template: %v", err)
+		return nil, fmt.Errorf("failed to parse reminders template: %v", err)
 	}
 
-	// Generate implementation file for this actor
+	// Generate reminders file for this actor
 	data := SingleActorTemplateData{
 		PackageName: actorModel.PackageName,
 		Actor:       actorModel.ActorInterface,
 	}
 
-	implFile, err := os.Create(filepath.Join(outputDir, "impl.go"))
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
 	if err != nil {
-		return fmt.Errorf("failed to create implementation file: %v", err)
+		return nil, fmt.Errorf("failed to execute reminders template: %v", err)
 	}
-	defer implFile.Close()
 
-	err = tmpl.Execute(implFile, data)
+	return buf.Bytes(), nil
+}
+
+func (g *Generator) generateActorMock(actorModel *ActorModel, options GenerationOptions) ([]byte, error) {
+	// Load template from embedded filesystem
+	tmpl, err := loadTemplate("mock.tmpl", options)
 	if err != nil {
-		return fmt.Errorf("failed to execute partial implementation template: %v", err)
+		return nil, fmt.Errorf("failed to parse mock template: %v", err)
 	}
 
-	return nil
+	// Generate mock file for this actor
+	data := SingleActorTemplateData{
+		PackageName: actorModel.PackageName,
+		Actor:       actorModel.ActorInterface,
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute mock template: %v", err)
+	}
+
+	return buf.Bytes(), nil
 }
 
-func (g *Generator) generateExampleApplication(model *GenerationModel, baseOutputDir string) error {
-	// Generate main.go
-	err := g.generateExampleMain(model, baseOutputDir)
+// generatePartialImplementation renders impl.go; in merge mode the existing file at implPath is kept and only extended.
+// The returned warnings describe the merge.
+func (g *Generator) generatePartialImplementation(actorModel *ActorModel, implPath string, options GenerationOptions) ([]byte, []string, error) {
+	// Load template from embedded filesystem
+	tmpl, err := loadTemplate("partial_impl.tmpl", options)
 	if err != nil {
-		return fmt.Errorf("failed to generate example main.go: %v", err)
+		return nil, nil, fmt.Errorf("failed to parse partial implementation template: %v", err)
+	}
+
+	// Generate implementation file for this actor
+	data := SingleActorTemplateData{
+		PackageName: actorModel.PackageName,
+		Actor:       actorModel.ActorInterface,
 	}
 
-	// Generate go.mod
-	err = g.generateExampleGoMod(model, baseOutputDir)
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
 	if err != nil {
-		return fmt.Errorf("failed to generate example go.mod: %v", err)
+		return nil, nil, fmt.Errorf("failed to execute partial implementation template: %v", err)
 	}
 
-	fmt.Printf("Generated example application files:\n")
-	fmt.Printf("  %s/main.go\n", baseOutputDir)
-	fmt.Printf("  %s/go.mod\n", baseOutputDir)
+	content := buf.Bytes()
+	if !options.MergeImpl {
+		return content, nil, nil
+	}
 
-	return nil
+	// In merge mode keep the existing implementation and only append stubs for new methods
+	existing, err := os.ReadFile(implPath)
+	if os.IsNotExist(err) {
+		return content, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read existing implementation file: %v", err)
+	}
+
+	// The previously generated interface tells spec methods apart from helpers of the implementation
+	previousMethods := make(map[string]bool)
+	if api, err := os.ReadFile(filepath.Join(filepath.Dir(implPath), "api.go")); err == nil {
+		if methods, err := interfaceMethods(api, actorModel.ActorInterface.InterfaceName); err == nil {
+			previousMethods = methods
+		}
+	}
+	result, err := mergeImplementation(existing, content, actorModel.ActorType, previousMethods)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to merge %s: %v", implPath, err)
+	}
+
+	var warnings []string
+	for _, name := range result.Added {
+		warnings = append(warnings, "added "+name)
+	}
+	for _, name := range result.Stale {
+		warnings = append(warnings, fmt.Sprintf("warning: method %s is no longer declared in the spec (kept)", name))
+	}
+	for _, method := range result.Changed {
+		warnings = append(warnings, fmt.Sprintf("warning: method signature changed in the spec to %s (kept, update the implementation)", method))
+	}
+	return result.Source, warnings, nil
 }
 
-func (g *Generator) generateExampleMain(model *GenerationModel, baseOutputDir string) error {
+func (g *Generator) generateExampleMain(model *GenerationModel, options GenerationOptions) ([]byte, error) {
 	// Load template from embedded filesystem
-	tmpl, err := getEmbeddedTemplate("example_main.tmpl")
+	tmpl, err := loadTemplate("example_main.tmpl", options)
 	if err != nil {
-		return fmt.Errorf("failed to parse example main template: %v", err)
+		return nil, fmt.Errorf("failed to parse example main template: %v", err)
 	}
 
 	// Generate main.go file
 	data := struct {
-		Actors     []ActorInterface
+		Actors     []ActorModel
 		ModuleName string
+		Transport  string
 	}{
-		Actors:     model.Actors,
-		ModuleName: "example-dapr-actors",
+		ModuleName: modulePath(options),
+		Transport:  transport(options),
 	}
-
-	mainFile, err := os.Create(filepath.Join(baseOutputDir, "main.go"))
-	if err != nil {
-		return fmt.Errorf("failed to create main.go file: %v", err)
+	for _, actor := range model.Actors {
+		data.Actors = append(data.Actors, ActorModel{
+			ActorType:      actor.ActorType,
+			PackageName:    actorPackageName(actor.ActorType, options),
+			ActorInterface: actor,
+		})
 	}
-	defer mainFile.Close()
 
-	err = tmpl.Execute(mainFile, data)
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
 	if err != nil {
-		return fmt.Errorf("failed to execute example main template: %v", err)
+		return nil, fmt.Errorf("failed to execute example main template: %v", err)
 	}
 
-	return nil
+	return buf.Bytes(), nil
 }
 
-func (g *Generator) generateExampleGoMod(model *GenerationModel, baseOutputDir string) error {
+func (g *Generator) generateExampleGoMod(model *GenerationModel, options GenerationOptions) ([]byte, error) {
 	// Load template from embedded filesystem
-	tmpl, err := getEmbeddedTemplate("example_gomod.tmpl")
+	tmpl, err := loadTemplate("example_gomod.tmpl", options)
 	if err != nil {
-		return fmt.Errorf("failed to parse example go.mod template: %v", err)
+		return nil, fmt.Errorf("failed to parse example go.mod template: %v", err)
 	}
 
 	// Generate go.mod file
 	data := struct {
 		ModuleName string
+		Requires   []string
 	}{
-		ModuleName: "example-dapr-actors",
+		ModuleName: modulePath(options),
+		Requires:   moduleRequires(model.Imports),
 	}
 
-	goModFile, err := os.Create(filepath.Join(baseOutputDir, "go.mod"))
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
 	if err != nil {
-		return fmt.Errorf("failed to create go.mod file: %v", err)
+		return nil, fmt.Errorf("failed to execute example go.mod template: %v", err)
 	}
-	defer goModFile.Close()
 
-	err = tmpl.Execute(goModFile, data)
-	if err != nil {
-		return fmt.Errorf("failed to execute example go.mod template: %v", err)
+	return buf.Bytes(), nil
+}
+
+// Utility functions
+
+// moduleVersions pins the modules of the built-in format mappings
+var moduleVersions = map[string]string{
+	"github.com/google/uuid": "v1.6.0",
+}
+
+// moduleRequires returns the go.mod requirements of the third-party packages of mapped types. Import paths are
+// required as module paths; modules without a pinned version are required at "latest", which the go command
+// resolves to the newest release on the first build.
+func moduleRequires(imports map[string]string) []string {
+	var requires []string
+	for _, importPath := range imports {
+		if isStandardImport(importPath) {
+			continue
+		}
+		version, ok := moduleVersions[importPath]
+		if !ok {
+			version = "latest"
+		}
+		requires = append(requires, importPath+" "+version)
 	}
+	sort.Strings(requires)
+	return requires
+}
 
-	return nil
+// actorPackageName returns the package name (and output subdirectory) of an actor: the configured
+// override, or the lowercased actor type followed by the package suffix
+func actorPackageName(actorType string, options GenerationOptions) string {
+	if packageName, ok := options.PackageNames[actorType]; ok {
+		return packageName
+	}
+	return strings.ToLower(actorType) + options.PackageSuffix
 }
 
-// Utility functions
+// printGeneratedFiles lists the written files grouped by package directory
+func printGeneratedFiles(files []GeneratedFile, baseOutputDir string) {
+	var dirs []string
+	byDir := make(map[string][]GeneratedFile)
+	for _, file := range files {
+		dir := filepath.Dir(file.Path)
+		if _, ok := byDir[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		byDir[dir] = append(byDir[dir], file)
+	}
+
+	for _, dir := range dirs {
+		switch dir {
+		case filepath.Clean(baseOutputDir):
+			fmt.Printf("Generated files in %s:\n", dir)
+		case filepath.Join(baseOutputDir, sharedTypesPackage):
+			fmt.Printf("Generated shared types package: %s\n", dir)
+		default:
+			fmt.Printf("Generated actor package: %s\n", dir)
+		}
+		for _, file := range byDir[dir] {
+			fmt.Printf("  %s\n", file.Path)
+			for _, warning := range file.Warnings {
+				fmt.Printf("  %s: %s\n", file.Path, warning)
+			}
+		}
+	}
+}
+
+// isNilableType reports whether a Go type already has a nil value (pointers, slices, maps and interfaces)
+func isNilableType(goType string) bool {
+	return strings.HasPrefix(goType, "*") ||
+		strings.HasPrefix(goType, "[]") ||
+		strings.HasPrefix(goType, "map[") ||
+		goType == "interface{}"
+}
+
+// mergeImports returns the sorted union of the given import paths
+func mergeImports(importLists ...[]string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, imports := range importLists {
+		for _, importPath := range imports {
+			if !seen[importPath] {
+				seen[importPath] = true
+				result = append(result, importPath)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// typeImports returns the sorted import paths needed by the given type definitions
+func typeImports(types TypeDefinitions, knownImports map[string]string) []string {
+	imports := make(map[string]bool)
+
+	// Union types marshal and unmarshal their variants
+	if len(types.Unions) > 0 {
+		imports["encoding/json"] = true
+		imports["fmt"] = true
+	}
+
+	// External types are referenced with their package qualifier (e.g. "time.Time", "[]uuid.UUID")
+	addQualified := func(goType string) {
+		goType = strings.TrimLeft(goType, "*[]")
+		goType = strings.TrimPrefix(goType, "map[string]")
+		goType = strings.TrimLeft(goType, "*[]")
+		if dot := strings.Index(goType, "."); dot > 0 {
+			if importPath, ok := knownImports[goType[:dot]]; ok {
+				imports[importPath] = true
+			}
+		}
+	}
+	for _, structType := range types.Structs {
+		for _, field := range structType.Fields {
+			addQualified(field.Type)
+		}
+	}
+	for _, aliasType := range types.Aliases {
+		addQualified(aliasType.AliasTarget)
+	}
+	for _, enumType := range types.Enums {
+		addQualified(enumType.BaseType)
+	}
+
+	result := make([]string, 0, len(imports))
+	for importPath := range imports {
+		result = append(result, importPath)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,279 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MergeResult describes the outcome of merging a freshly generated implementation into existing code
+type MergeResult struct {
+	Source  []byte   // Merged, gofmt'ed source
+	Added   []string // Declarations appended from the generated implementation
+	Stale   []string // Methods of the previous interface that are no longer declared in the spec (kept as is)
+	Changed []string // Methods whose signature differs from the spec, with the spec's signature (kept as is)
+}
+
+// mergeImplementation merges a freshly generated implementation into an existing implementation file.
+// Existing declarations and method bodies are preserved; declarations of the generated file that are
+// missing from the existing file are appended, together with the imports they need. previousMethods are
+// the methods of the previously generated interface; only those are reported when the spec drops them.
+func mergeImplementation(existing, generated []byte, actorType string, previousMethods map[string]bool) (*MergeResult, error) {
+	fset := token.NewFileSet()
+	existingFile, err := parser.ParseFile(fset, "impl.go", existing, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse existing implementation: %v", err)
+	}
+	generatedFile, err := parser.ParseFile(fset, "impl.go.generated", generated, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse generated implementation: %v", err)
+	}
+
+	existingDecls := make(map[string]bool)
+	existingMethods := make(map[string]*ast.FuncDecl)
+	for _, decl := range existingFile.Decls {
+		for _, name := range declNames(decl) {
+			existingDecls[name] = true
+		}
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && receiverTypeName(funcDecl) == actorType {
+			existingMethods[funcDecl.Name.Name] = funcDecl
+		}
+	}
+
+	// Collect the generated declarations that the existing file lacks
+	result := &MergeResult{}
+	generatedDecls := make(map[string]bool)
+	var appended []string
+	var appendedDecls []ast.Decl
+	for _, decl := range generatedFile.Decls {
+		names := declNames(decl)
+		missing := false
+		for _, name := range names {
+			generatedDecls[name] = true
+			if !existingDecls[name] {
+				missing = true
+			}
+		}
+		if !missing {
+			// Existing methods are kept even when the spec changed their signature
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && receiverTypeName(funcDecl) == actorType {
+				if existingMethod := existingMethods[funcDecl.Name.Name]; signature(existingMethod) != signature(funcDecl) {
+					result.Changed = append(result.Changed, funcDecl.Name.Name+signature(funcDecl))
+				}
+			}
+			continue
+		}
+
+		start := decl.Pos()
+		if doc := declDoc(decl); doc != nil {
+			start = doc.Pos()
+		}
+		appended = append(appended, string(generated[fset.Position(start).Offset:fset.Position(decl.End()).Offset]))
+		appendedDecls = append(appendedDecls, decl)
+		result.Added = append(result.Added, names...)
+	}
+
+	// Report methods of the previous interface that the spec no longer declares; helpers are not reported
+	for _, decl := range existingFile.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || receiverTypeName(funcDecl) != actorType || !previousMethods[funcDecl.Name.Name] {
+			continue
+		}
+		if !generatedDecls[actorType+"."+funcDecl.Name.Name] {
+			result.Stale = append(result.Stale, funcDecl.Name.Name)
+		}
+	}
+
+	if len(appended) == 0 {
+		result.Source = existing
+		return result, nil
+	}
+
+	// Imports referenced by the appended declarations
+	existingImports := make(map[string]bool)
+	for _, importSpec := range existingFile.Imports {
+		importPath, _ := strconv.Unquote(importSpec.Path.Value)
+		existingImports[importPath] = true
+	}
+	usedPackages := referencedPackages(appendedDecls)
+	var missingImports []string
+	for _, importSpec := range generatedFile.Imports {
+		importPath, _ := strconv.Unquote(importSpec.Path.Value)
+		name := ImportPathToAssumedName(importPath)
+		if importSpec.Name != nil {
+			name = importSpec.Name.Name
+		}
+		if usedPackages[name] && !existingImports[importPath] {
+			missingImports = append(missingImports, importSpec.Path.Value)
+		}
+	}
+	sort.Strings(missingImports)
+
+	// Append the declarations first so that the import edit does not shift their offsets
+	source := string(existing)
+	source = strings.TrimRight(source, "\n") + "\n\n" + strings.Join(appended, "\n\n") + "\n"
+	source = insertImports(source, fset, existingFile, missingImports)
+
+	formatted, err := format.Source([]byte(source))
+	if err != nil {
+		return nil, fmt.Errorf("failed to format merged implementation: %v", err)
+	}
+	result.Source = formatted
+	return result, nil
+}
+
+// insertImports adds quoted import paths to the import declaration of a parsed file's source
+func insertImports(source string, fset *token.FileSet, file *ast.File, quotedPaths []string) string {
+	if len(quotedPaths) == 0 {
+		return source
+	}
+
+	var importDecl *ast.GenDecl
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT {
+			importDecl = genDecl
+			break
+		}
+	}
+
+	lines := "\t" + strings.Join(quotedPaths, "\n\t") + "\n"
+	switch {
+	case importDecl == nil:
+		// No imports yet: add a block right after the package clause
+		offset := fset.Position(file.Name.End()).Offset
+		return source[:offset] + "\n\nimport (\n" + lines + ")" + source[offset:]
+	case importDecl.Lparen.IsValid():
+		offset := fset.Position(importDecl.Rparen).Offset
+		return source[:offset] + lines + source[offset:]
+	default:
+		// Single import without parentheses: turn it into a block
+		start := fset.Position(importDecl.Pos()).Offset
+		end := fset.Position(importDecl.End()).Offset
+		spec := strings.TrimSpace(strings.TrimPrefix(source[start:end], "import"))
+		return source[:start] + "import (\n\t" + spec + "\n" + lines + ")" + source[end:]
+	}
+}
+
+// signature renders the parameter and result types of a function, e.g. "(context.Context, SetRequest) error"
+func signature(funcDecl *ast.FuncDecl) string {
+	fieldTypes := func(fields *ast.FieldList) []string {
+		var result []string
+		if fields == nil {
+			return result
+		}
+		for _, field := range fields.List {
+			for i := 0; i < max(len(field.Names), 1); i++ {
+				result = append(result, types.ExprString(field.Type))
+			}
+		}
+		return result
+	}
+	params := "(" + strings.Join(fieldTypes(funcDecl.Type.Params), ", ") + ")"
+	switch results := fieldTypes(funcDecl.Type.Results); len(results) {
+	case 0:
+		return params
+	case 1:
+		return params + " " + results[0]
+	default:
+		return params + " (" + strings.Join(results, ", ") + ")"
+	}
+}
+
+// interfaceMethods returns the names of the methods declared by the named interface in a Go source file
+func interfaceMethods(source []byte, interfaceName string) (map[string]bool, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", source, 0)
+	if err != nil {
+		return nil, err
+	}
+	methods := make(map[string]bool)
+	ast.Inspect(file, func(node ast.Node) bool {
+		typeSpec, ok := node.(*ast.TypeSpec)
+		if !ok || typeSpec.Name.Name != interfaceName {
+			return true
+		}
+		if interfaceType, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+			for _, method := range interfaceType.Methods.List {
+				for _, name := range method.Names {
+					methods[name.Name] = true
+				}
+			}
+		}
+		return false
+	})
+	return methods, nil
+}
+
+// declNames returns the identifiers declared by a top-level declaration; methods are keyed as "Receiver.Method"
+func declNames(decl ast.Decl) []string {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if receiver := receiverTypeName(d); receiver != "" {
+			return []string{receiver + "." + d.Name.Name}
+		}
+		return []string{d.Name.Name}
+	case *ast.GenDecl:
+		var names []string
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				names = append(names, s.Name.Name)
+			case *ast.ValueSpec:
+				for _, name := range s.Names {
+					if name.Name != "_" {
+						names = append(names, name.Name)
+					}
+				}
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// declDoc returns the doc comment attached to a top-level declaration
+func declDoc(decl ast.Decl) *ast.CommentGroup {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		return d.Doc
+	case *ast.GenDecl:
+		return d.Doc
+	}
+	return nil
+}
+
+// receiverTypeName returns the receiver type name of a method, or "" for plain functions
+func receiverTypeName(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return ""
+	}
+	expr := funcDecl.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// referencedPackages returns the package qualifiers used in selector expressions of the given declarations
+func referencedPackages(decls []ast.Decl) map[string]bool {
+	packages := make(map[string]bool)
+	for _, decl := range decls {
+		ast.Inspect(decl, func(node ast.Node) bool {
+			if selector, ok := node.(*ast.SelectorExpr); ok {
+				if ident, ok := selector.X.(*ast.Ident); ok {
+					packages[ident.Name] = true
+				}
+			}
+			return true
+		})
+	}
+	return packages
+}
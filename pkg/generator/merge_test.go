@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeImplementation(t *testing.T) {
+	existing := `package counter
+
+import (
+	"context"
+	"errors"
+)
+
+type Counter struct{}
+
+func (a *Counter) Get(ctx context.Context) (*int, error) {
+	return nil, errors.New("custom business logic")
+}
+
+func (a *Counter) Set(ctx context.Context, value int) error {
+	return nil
+}
+
+func (a *Counter) Reset(ctx context.Context) error {
+	return nil
+}
+
+func (a *Counter) Describe() string {
+	return "helper"
+}
+`
+	generated := `package counter
+
+import (
+	"context"
+	"errors"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Counter struct{}
+
+func (a *Counter) Get(ctx context.Context) (*int, error) {
+	return nil, errors.New("Get method is not implemented")
+}
+
+func (a *Counter) Set(ctx context.Context, request SetRequest) error {
+	return errors.New("Set method is not implemented")
+}
+
+func (a *Counter) Export(ctx context.Context) (*yaml.Node, error) {
+	return nil, errors.New("Export method is not implemented")
+}
+`
+	previousMethods := map[string]bool{"Get": true, "Set": true, "Reset": true}
+
+	result, err := mergeImplementation([]byte(existing), []byte(generated), "Counter", previousMethods)
+	if err != nil {
+		t.Fatalf("Failed to merge implementation: %v", err)
+	}
+
+	if strings.Join(result.Added, ",") != "Counter.Export" {
+		t.Errorf("Expected Export to be added, got %v", result.Added)
+	}
+	// Only methods of the previous interface are stale; helpers are not reported
+	if strings.Join(result.Stale, ",") != "Reset" {
+		t.Errorf("Expected Reset to be reported as stale, got %v", result.Stale)
+	}
+	if strings.Join(result.Changed, ",") != "Set(context.Context, SetRequest) error" {
+		t.Errorf("Expected the changed signature of Set to be reported, got %v", result.Changed)
+	}
+
+	// The import of a versioned package path is matched by its package name
+	for _, expected := range []string{`"gopkg.in/yaml.v3"`, `errors.New("custom business logic")`, "func (a *Counter) Set(ctx context.Context, value int) error"} {
+		if !strings.Contains(string(result.Source), expected) {
+			t.Errorf("Expected '%s' in merged source. Got:\n%s", expected, result.Source)
+		}
+	}
+}
@@ -2,12 +2,30 @@ package generator
 
 import "github.com/getkin/kin-openapi/openapi3"
 
+// Constraints represents validation constraints declared on a property in the intermediate model
+type Constraints struct {
+	Minimum          *float64
+	Maximum          *float64
+	ExclusiveMinimum bool
+	ExclusiveMaximum bool
+	MinLength        uint64
+	MaxLength        *uint64
+	Pattern          string
+	MinItems         uint64
+	MaxItems         *uint64
+	Enum             []string // allowed values of non-string enums (string enums become EnumType)
+}
+
 // Field represents a struct field in the intermediate model
 type Field struct {
-	Name    string
-	Type    string
-	JSONTag string
-	Comment string
+	Name        string
+	Type        string
+	JSONTag     string
+	Comment     string
+	Embedded    bool // Embedded struct from allOf composition (Name equals Type, no JSON tag)
+	Optional    bool // Property is not listed as required
+	Nullable    bool // Property allows null values
+	Constraints Constraints
 }
 
 // StructType represents a struct type definition in the intermediate model
@@ -33,11 +51,34 @@ type EnumType struct {
 	Values      []string // enum constant values
 }
 
+// UnionVariant represents one concrete variant of a discriminated union
+type UnionVariant struct {
+	TypeName string   // Go type of the variant (a struct type)
+	Values   []string // discriminator values selecting this variant
+}
+
+// UnionType represents a discriminated union (oneOf/anyOf with discriminator) in the intermediate model
+type UnionType struct {
+	Name          string
+	Description   string
+	Discriminator string // JSON property name used to select the variant
+	Variants      []UnionVariant
+}
+
 // TypeDefinitions represents a collection of type definitions
 type TypeDefinitions struct {
 	Structs []StructType
 	Aliases []TypeAlias
 	Enums   []EnumType
+	Unions  []UnionType
+}
+
+// ErrorResponse represents a documented non-2xx response of an actor method with a JSON body
+type ErrorResponse struct {
+	StatusCode  int    // HTTP status code, 0 for the default response
+	TypeName    string // Go error type wrapping the body
+	BodyType    string // Go type of the response body
+	Description string
 }
 
 // Method represents an actor method in the intermediate model
@@ -46,7 +87,37 @@ type Method struct {
 	Comment     string
 	HasRequest  bool
 	RequestType string
-	ReturnType  string
+	HasReturn   bool   // The success response has a body; methods without one only return an error
+	ReturnType  string // Go type of the success response body, set when HasReturn
+	Errors      []ErrorResponse
+}
+
+// StateKey represents a typed entry of an actor's state declared in the spec
+type StateKey struct {
+	Key         string // Key of the entry in the Dapr actor state store
+	Type        string // Go type of the stored value
+	Description string
+}
+
+// Reminder represents a reminder or timer declared for an actor, delivered to a typed callback method
+type Reminder struct {
+	Name        string // Name the reminder or timer is registered with in Dapr
+	Callback    string // Actor method invoked when it fires
+	PayloadType string // Go type of the payload, empty if it carries no data
+	Comment     string
+}
+
+// RuntimeConfig represents the Dapr runtime settings declared for an actor (x-dapr-actor-config).
+// Durations are kept in the Dapr format, e.g. "1h" or "30s"; empty values keep the Dapr defaults.
+type RuntimeConfig struct {
+	IdleTimeout                string
+	ScanInterval               string
+	DrainOngoingCallTimeout    string
+	DrainRebalancedActors      *bool
+	ReentrancyEnabled          bool
+	ReentrancyMaxStackDepth    int
+	RemindersStoragePartitions int
+	Serializer                 string
 }
 
 // ActorOperation represents an OpenAPI operation grouped by actor type
@@ -62,15 +133,61 @@ type ActorInterface struct {
 	InterfaceName string
 	InterfaceDesc string
 	Methods       []Method
+	// State lists the typed state entries declared for this actor (x-dapr-actor-state)
+	State []StateKey
+	// Reminders and Timers list the declared reminders and timers (x-dapr-reminders, x-dapr-timers)
+	Reminders []Reminder
+	Timers    []Reminder
+	// Config holds the declared runtime settings (x-dapr-actor-config), nil if none are declared
+	Config *RuntimeConfig
 	// Types contains type definitions specific to this actor only
 	Types TypeDefinitions
 }
 
+// Callbacks returns the reminders and timers of the actor, whose callbacks are part of its interface
+func (a ActorInterface) Callbacks() []Reminder {
+	return append(append([]Reminder(nil), a.Reminders...), a.Timers...)
+}
+
+// ErrorType is a Go error type generated for the error responses of an actor's methods
+type ErrorType struct {
+	Name        string
+	BodyType    string
+	StatusCodes []int // Status codes the error is documented with, 0 for the default response
+}
+
+// ErrorTypes returns the error types declared by the actor's methods, in order of first declaration
+func (a ActorInterface) ErrorTypes() []ErrorType {
+	var result []ErrorType
+	index := make(map[string]int)
+	for _, method := range a.Methods {
+		for _, response := range method.Errors {
+			i, exists := index[response.TypeName]
+			if !exists {
+				i = len(result)
+				index[response.TypeName] = i
+				result = append(result, ErrorType{Name: response.TypeName, BodyType: response.BodyType})
+			}
+			known := false
+			for _, statusCode := range result[i].StatusCodes {
+				known = known || statusCode == response.StatusCode
+			}
+			if !known {
+				result[i].StatusCodes = append(result[i].StatusCodes, response.StatusCode)
+			}
+		}
+	}
+	return result
+}
+
 // GenerationModel represents the complete intermediate data structure
 // that is independent of any specific schema format (OpenAPI, etc.)
 type GenerationModel struct {
 	// Actors contains all actor interfaces with their methods and actor-specific types
 	Actors []ActorInterface
+	// Imports maps package qualifiers of external types used in type definitions to their import paths
+	// e.g. "time" -> "time", "uuid" -> "github.com/google/uuid"
+	Imports map[string]string
 }
 
 // ActorModel represents a single actor's complete model for generation
@@ -78,7 +195,9 @@ type ActorModel struct {
 	ActorType      string
 	PackageName    string
 	Types          TypeDefinitions
+	SharedTypes    TypeDefinitions // Types re-exported from the shared types package
 	ActorInterface ActorInterface
+	Imports        map[string]string // package qualifier -> import path for external types
 }
 
 // TypesTemplateData represents data for types template generation
@@ -99,8 +218,26 @@ type SingleActorTemplateData struct {
 	Actor       ActorInterface
 }
 
+// GeneratedFile represents a rendered output file
+type GeneratedFile struct {
+	Path     string
+	Content  []byte
+	Warnings []string // Printed when the file is written, e.g. methods an impl.go merge kept as is
+}
+
 // GenerationOptions represents options for controlling what gets generated
 type GenerationOptions struct {
-	GenerateImpl    bool // Generate partial implementation stubs
-	GenerateExample bool // Generate example main.go, go.mod, etc.
+	GenerateImpl       bool              // Generate partial implementation stubs
+	MergeImpl          bool              // Merge stubs for new methods into an existing impl.go instead of overwriting it
+	GenerateMock       bool              // Generate a mock implementing the actor interface and the client interface
+	GenerateExample    bool              // Generate example main.go, go.mod, etc.
+	OptionalPointers   bool              // Generate optional and nullable fields as pointer types
+	GenerateValidation bool              // Generate Validate() methods and validate requests before invoking actor methods
+	SharedTypes        bool              // Emit types used by more than one actor into a shared types package
+	ModulePath         string            // Go import path of the output directory (defaults to example-dapr-actors)
+	PackageSuffix      string            // Appended to the lowercased actor type to form the package name, e.g. "actor"
+	PackageNames       map[string]string // Package names overriding the default, keyed by actor type
+	TemplatesDir       string            // Directory whose templates override the embedded ones of the same file name
+	InModule           bool              // The output directory belongs to an existing module, so the example gets no go.mod
+	Transport          string            // Transport of the example application (defaults to TransportHTTPChi)
 }
@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DetectModulePath returns the Go import path of dir, derived from the go.mod of the enclosing module:
+// the module path followed by the path of dir relative to the module root. dir does not need to exist yet.
+func DetectModulePath(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %v", dir, err)
+	}
+
+	for root := absDir; ; root = filepath.Dir(root) {
+		goModPath := filepath.Join(root, "go.mod")
+		data, err := os.ReadFile(goModPath)
+		if err == nil {
+			module, err := parseModulePath(data)
+			if err != nil {
+				return "", fmt.Errorf("invalid %s: %v", goModPath, err)
+			}
+			rel, err := filepath.Rel(root, absDir)
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve %s relative to %s: %v", dir, root, err)
+			}
+			if rel == "." {
+				return module, nil
+			}
+			return module + "/" + filepath.ToSlash(rel), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to read %s: %v", goModPath, err)
+		}
+		if filepath.Dir(root) == root {
+			return "", fmt.Errorf("no go.mod found in %s or any parent directory", absDir)
+		}
+	}
+}
+
+// parseModulePath extracts the module path from the module directive of a go.mod file
+func parseModulePath(data []byte) (string, error) {
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if comment := strings.Index(line, "//"); comment >= 0 {
+			line = strings.TrimSpace(line[:comment])
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != "module" {
+			continue
+		}
+		module := fields[1]
+		if unquoted, err := strconv.Unquote(module); err == nil {
+			module = unquoted
+		}
+		return module, nil
+	}
+	return "", fmt.Errorf("no module directive")
+}
@@ -0,0 +1,45 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDetectModulePath(t *testing.T) {
+	root := t.TempDir()
+	goMod := "// Service module\nmodule \"github.com/acme/service\" // main module\n\ngo 1.22\n"
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+
+	tests := []struct {
+		dir      string
+		expected string
+	}{
+		{root, "github.com/acme/service"},
+		{filepath.Join(root, "internal", "actors"), "github.com/acme/service/internal/actors"},
+	}
+	for _, test := range tests {
+		modulePath, err := DetectModulePath(test.dir)
+		if err != nil {
+			t.Fatalf("Failed to detect the module path of %s: %v", test.dir, err)
+		}
+		if modulePath != test.expected {
+			t.Errorf("Expected module path %s for %s, got %s", test.expected, test.dir, modulePath)
+		}
+	}
+}
+
+func TestDetectModulePathWithoutModule(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("go 1.22\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+
+	_, err := DetectModulePath(filepath.Join(root, "generated"))
+	if err == nil || !strings.Contains(err.Error(), "no module directive") {
+		t.Errorf("Expected a missing module directive error, got: %v", err)
+	}
+}
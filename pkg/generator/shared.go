@@ -0,0 +1,210 @@
+package generator
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// sharedTypesPackage is the package name (and output subdirectory) of the shared types package
+const sharedTypesPackage = "types"
+
+// defaultModulePath is the Go import path of the output directory when none is configured
+const defaultModulePath = "example-dapr-actors"
+
+// SharedTypesRef describes the shared types re-exported by an actor package as type aliases
+type SharedTypesRef struct {
+	Package   string   // Package qualifier of the shared types package
+	Names     []string // Type names re-exported as aliases
+	Constants []string // Enum constants re-exported from the shared package
+}
+
+// modulePath returns the configured module path or the default one
+func modulePath(options GenerationOptions) string {
+	if options.ModulePath != "" {
+		return options.ModulePath
+	}
+	return defaultModulePath
+}
+
+// splitSharedTypes returns the types used by more than one actor. A type is shared only when
+// every actor declares it identically and all the types it references are shared as well.
+// Variants of a union that stays local are kept local too, since the union declares methods on them.
+func splitSharedTypes(actors []ActorInterface) TypeDefinitions {
+	count := make(map[string]int)
+	first := make(map[string]interface{})
+	identical := make(map[string]bool)
+	references := make(map[string][]string)
+	variants := make(map[string][]string) // union name -> variant type names, across all actors
+
+	record := func(name string, definition interface{}, refs []string) {
+		count[name]++
+		if previous, ok := first[name]; ok {
+			identical[name] = identical[name] && reflect.DeepEqual(previous, definition)
+			return
+		}
+		first[name] = definition
+		identical[name] = true
+		references[name] = refs
+	}
+
+	for _, actor := range actors {
+		for _, structType := range actor.Types.Structs {
+			var refs []string
+			for _, field := range structType.Fields {
+				refs = append(refs, ElementTypeName(field.Type))
+			}
+			record(structType.Name, structType, refs)
+		}
+		for _, aliasType := range actor.Types.Aliases {
+			record(aliasType.Name, aliasType, []string{ElementTypeName(aliasType.AliasTarget)})
+		}
+		for _, enumType := range actor.Types.Enums {
+			record(enumType.Name, enumType, nil)
+		}
+		for _, unionType := range actor.Types.Unions {
+			var refs []string
+			for _, variant := range unionType.Variants {
+				refs = append(refs, variant.TypeName)
+			}
+			variants[unionType.Name] = append(variants[unionType.Name], refs...)
+			record(unionType.Name, unionType, refs)
+		}
+	}
+
+	shared := make(map[string]bool)
+	for name := range first {
+		if count[name] > 1 && identical[name] {
+			shared[name] = true
+		}
+	}
+
+	// Drop shared candidates that reference types which stay local to an actor
+	for changed := true; changed; {
+		changed = false
+		for name := range shared {
+			for _, ref := range references[name] {
+				if _, declared := first[ref]; declared && !shared[ref] {
+					delete(shared, name)
+					changed = true
+					break
+				}
+			}
+		}
+		for union, names := range variants {
+			if shared[union] {
+				continue
+			}
+			for _, name := range names {
+				if shared[name] {
+					delete(shared, name)
+					changed = true
+				}
+			}
+		}
+	}
+
+	var result TypeDefinitions
+	for name := range shared {
+		switch definition := first[name].(type) {
+		case StructType:
+			result.Structs = append(result.Structs, definition)
+		case TypeAlias:
+			result.Aliases = append(result.Aliases, definition)
+		case EnumType:
+			result.Enums = append(result.Enums, definition)
+		case UnionType:
+			result.Unions = append(result.Unions, definition)
+		}
+	}
+	sort.Slice(result.Structs, func(i, j int) bool { return result.Structs[i].Name < result.Structs[j].Name })
+	sort.Slice(result.Aliases, func(i, j int) bool { return result.Aliases[i].Name < result.Aliases[j].Name })
+	sort.Slice(result.Enums, func(i, j int) bool { return result.Enums[i].Name < result.Enums[j].Name })
+	sort.Slice(result.Unions, func(i, j int) bool { return result.Unions[i].Name < result.Unions[j].Name })
+	return result
+}
+
+// partitionTypes splits an actor's types into the ones declared locally and the ones taken from the shared package
+func partitionTypes(types TypeDefinitions, shared TypeDefinitions) (local TypeDefinitions, fromShared TypeDefinitions) {
+	names := sharedTypeNames(shared)
+	for _, structType := range types.Structs {
+		if names[structType.Name] {
+			fromShared.Structs = append(fromShared.Structs, structType)
+		} else {
+			local.Structs = append(local.Structs, structType)
+		}
+	}
+	for _, aliasType := range types.Aliases {
+		if names[aliasType.Name] {
+			fromShared.Aliases = append(fromShared.Aliases, aliasType)
+		} else {
+			local.Aliases = append(local.Aliases, aliasType)
+		}
+	}
+	for _, enumType := range types.Enums {
+		if names[enumType.Name] {
+			fromShared.Enums = append(fromShared.Enums, enumType)
+		} else {
+			local.Enums = append(local.Enums, enumType)
+		}
+	}
+	for _, unionType := range types.Unions {
+		if names[unionType.Name] {
+			fromShared.Unions = append(fromShared.Unions, unionType)
+		} else {
+			local.Unions = append(local.Unions, unionType)
+		}
+	}
+	return local, fromShared
+}
+
+// sharedTypeNames returns the set of type names declared in the given definitions
+func sharedTypeNames(types TypeDefinitions) map[string]bool {
+	names := make(map[string]bool)
+	for _, structType := range types.Structs {
+		names[structType.Name] = true
+	}
+	for _, aliasType := range types.Aliases {
+		names[aliasType.Name] = true
+	}
+	for _, enumType := range types.Enums {
+		names[enumType.Name] = true
+	}
+	for _, unionType := range types.Unions {
+		names[unionType.Name] = true
+	}
+	return names
+}
+
+// sharedTypesRef lists the aliases and constants an actor package re-exports from the shared package
+func sharedTypesRef(fromShared TypeDefinitions) *SharedTypesRef {
+	ref := &SharedTypesRef{Package: sharedTypesPackage}
+	for name := range sharedTypeNames(fromShared) {
+		ref.Names = append(ref.Names, name)
+	}
+	for _, unionType := range fromShared.Unions {
+		ref.Names = append(ref.Names, unionType.Name+"Variant")
+	}
+	for _, enumType := range fromShared.Enums {
+		for _, value := range enumType.Values {
+			ref.Constants = append(ref.Constants, enumType.Name+toPascalCase(value))
+		}
+	}
+	sort.Strings(ref.Names)
+	sort.Strings(ref.Constants)
+	return ref
+}
+
+// ElementTypeName strips pointer, slice and map prefixes from a Go type
+// e.g., "[]*Item" -> "Item", "map[string][]Item" -> "Item"
+func ElementTypeName(goType string) string {
+	for {
+		trimmed := strings.TrimPrefix(goType, "*")
+		trimmed = strings.TrimPrefix(trimmed, "[]")
+		trimmed = strings.TrimPrefix(trimmed, "map[string]")
+		if trimmed == goType {
+			return goType
+		}
+		goType = trimmed
+	}
+}
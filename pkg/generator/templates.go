@@ -1,7 +1,13 @@
 package generator
 
 import (
+	"bytes"
 	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 )
@@ -9,15 +15,90 @@ import (
 //go:embed templates/*.tmpl
 var templatesFS embed.FS
 
-// getEmbeddedTemplate loads a template from the embedded filesystem
-func getEmbeddedTemplate(templateName string) (*template.Template, error) {
-	// Create template with helper functions
-	tmpl := template.New(templateName).Funcs(template.FuncMap{
+// newTemplate creates a template with the helper functions available to every template
+func newTemplate(templateName string) *template.Template {
+	return template.New(templateName).Funcs(template.FuncMap{
 		"ToLower":      strings.ToLower,
 		"ToPascalCase": toPascalCase,
 	})
+}
+
+// getEmbeddedTemplate loads a template from the embedded filesystem
+func getEmbeddedTemplate(templateName string) (*template.Template, error) {
+	return newTemplate(templateName).ParseFS(templatesFS, "templates/"+templateName)
+}
+
+// loadTemplate loads a template from the templates directory of the options if it contains a file
+// of the same name, and from the embedded filesystem otherwise
+func loadTemplate(templateName string, options GenerationOptions) (*template.Template, error) {
+	if options.TemplatesDir != "" {
+		path := filepath.Join(options.TemplatesDir, templateName)
+		_, err := os.Stat(path)
+		if err == nil {
+			return newTemplate(templateName).ParseFiles(path)
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read template override %s: %v", path, err)
+		}
+	}
+	return getEmbeddedTemplate(templateName)
+}
+
+// Subdirectories of the templates directory holding additional user templates, rendered once per actor
+// into the actor's package directory and once per model into the base output directory respectively
+const (
+	actorTemplatesDir = "actor"
+	modelTemplatesDir = "model"
+)
 
-	return tmpl.ParseFS(templatesFS, "templates/"+templateName)
+// checkTemplatesDir verifies that the templates directory exists and that every template at its top level
+// overrides an embedded template, so that a misspelled override is not silently ignored
+func checkTemplatesDir(options GenerationOptions) error {
+	if options.TemplatesDir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(options.TemplatesDir)
+	if err != nil {
+		return fmt.Errorf("failed to read templates directory: %v", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".tmpl") {
+			continue
+		}
+		if _, err := fs.Stat(templatesFS, "templates/"+entry.Name()); err != nil {
+			return fmt.Errorf("template %s in %s does not override a built-in template; put additional templates in %s/ (rendered per actor) or %s/ (rendered once)",
+				entry.Name(), options.TemplatesDir, actorTemplatesDir, modelTemplatesDir)
+		}
+	}
+	return nil
+}
+
+// userTemplates returns the paths of the additional templates in a subdirectory of the templates directory,
+// sorted by name. The generated file is named after the template without the .tmpl suffix.
+func userTemplates(options GenerationOptions, subdir string) ([]string, error) {
+	if options.TemplatesDir == "" {
+		return nil, nil
+	}
+	paths, err := filepath.Glob(filepath.Join(options.TemplatesDir, subdir, "*.tmpl"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s templates: %v", subdir, err)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// renderUserTemplate renders an additional user template with the helper functions of the embedded templates
+func renderUserTemplate(path string, data interface{}) ([]byte, error) {
+	tmpl, err := newTemplate(filepath.Base(path)).ParseFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %v", path, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template %s: %v", path, err)
+	}
+	return buf.Bytes(), nil
 }
 
 // toPascalCase converts a string to PascalCase
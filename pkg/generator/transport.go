@@ -0,0 +1,32 @@
+package generator
+
+import "fmt"
+
+// Transports the example application can serve the actors with
+const (
+	TransportHTTP    = "http"     // HTTP service of the Dapr Go SDK with its default router
+	TransportHTTPChi = "http-chi" // HTTP service with a chi router and demonstration middleware (default)
+	TransportGRPC    = "grpc"     // Rejected: the gRPC service of the Dapr Go SDK cannot host actors
+)
+
+// ValidateTransport checks that the example application can be generated for a transport.
+// An empty transport selects TransportHTTPChi.
+func ValidateTransport(transport string) error {
+	switch transport {
+	case "", TransportHTTP, TransportHTTPChi:
+		return nil
+	case TransportGRPC:
+		return fmt.Errorf("transport %s is not supported: the gRPC service of the Dapr Go SDK (service/grpc) does not support hosting actors; use %s or %s",
+			TransportGRPC, TransportHTTP, TransportHTTPChi)
+	default:
+		return fmt.Errorf("unknown transport '%s': expected %s or %s", transport, TransportHTTP, TransportHTTPChi)
+	}
+}
+
+// transport returns the transport of the example application selected by the options
+func transport(options GenerationOptions) string {
+	if options.Transport == "" {
+		return TransportHTTPChi
+	}
+	return options.Transport
+}
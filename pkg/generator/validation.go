@@ -0,0 +1,373 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ValidationModel holds the rendered validation code for the types of a single actor package
+type ValidationModel struct {
+	Structs       []ValidatedStruct
+	Unions        []ValidatedUnion
+	Patterns      []PatternVar
+	Imports       []string
+	SharedPackage string // Package qualifier of the shared types package when nested shared values are validated
+}
+
+// ValidatedStruct holds the statements of the validate method generated for a struct
+type ValidatedStruct struct {
+	Name       string
+	Statements []string
+}
+
+// ValidatedUnion holds the variants whose validate method is called for a union
+type ValidatedUnion struct {
+	Name     string
+	Variants []string
+}
+
+// PatternVar is a package-level compiled regular expression used by validation code
+type PatternVar struct {
+	Name    string
+	Pattern string
+}
+
+// validationBuilder renders validation statements for the types of a single actor package
+type validationBuilder struct {
+	structs  map[string]bool
+	unions   map[string]bool
+	enums    map[string][]string // enum name -> allowed values
+	aliases  map[string]string
+	shared   map[string]bool   // structs and unions declared in the shared types package
+	patterns map[string]string // pattern -> variable name
+	imports  map[string]bool
+	nested   bool // whether shared values are validated through their exported Validate method
+}
+
+// buildValidation renders the validate methods for all structs in the given type definitions.
+// Types taken from the shared types package are validated through their exported Validate method.
+// Patterns that Go's regexp package cannot compile (e.g. ECMA-262 lookarounds) are reported as errors.
+func buildValidation(types TypeDefinitions, fromShared TypeDefinitions) (*ValidationModel, error) {
+	b := &validationBuilder{
+		structs:  make(map[string]bool),
+		unions:   make(map[string]bool),
+		enums:    make(map[string][]string),
+		aliases:  make(map[string]string),
+		shared:   make(map[string]bool),
+		patterns: make(map[string]string),
+		imports:  map[string]bool{"strings": true},
+	}
+	for _, structType := range types.Structs {
+		b.structs[structType.Name] = true
+	}
+	for _, unionType := range types.Unions {
+		b.unions[unionType.Name] = true
+	}
+	for _, enumType := range types.Enums {
+		b.enums[enumType.Name] = enumType.Values
+	}
+	for _, aliasType := range types.Aliases {
+		b.aliases[aliasType.Name] = aliasType.AliasTarget
+	}
+	for _, structType := range fromShared.Structs {
+		b.structs[structType.Name] = true
+		b.shared[structType.Name] = true
+	}
+	for _, unionType := range fromShared.Unions {
+		b.unions[unionType.Name] = true
+		b.shared[unionType.Name] = true
+	}
+	for _, enumType := range fromShared.Enums {
+		b.enums[enumType.Name] = enumType.Values
+	}
+	for _, aliasType := range fromShared.Aliases {
+		b.aliases[aliasType.Name] = aliasType.AliasTarget
+	}
+
+	model := &ValidationModel{}
+	for _, structType := range types.Structs {
+		validated := ValidatedStruct{Name: structType.Name}
+		for _, field := range structType.Fields {
+			if pattern := field.Constraints.Pattern; pattern != "" {
+				if _, err := regexp.Compile(pattern); err != nil {
+					return nil, fmt.Errorf("schema %s property %s: pattern %q is not supported by Go regular expressions: %v",
+						structType.Name, strings.Split(field.JSONTag, ",")[0], pattern, err)
+				}
+			}
+			validated.Statements = append(validated.Statements, b.fieldStatements(field)...)
+		}
+		model.Structs = append(model.Structs, validated)
+	}
+
+	for _, unionType := range types.Unions {
+		validated := ValidatedUnion{Name: unionType.Name}
+		for _, variant := range unionType.Variants {
+			if b.kind(variant.TypeName) == "struct" {
+				validated.Variants = append(validated.Variants, variant.TypeName)
+			}
+		}
+		model.Unions = append(model.Unions, validated)
+	}
+
+	for pattern, name := range b.patterns {
+		model.Patterns = append(model.Patterns, PatternVar{Name: name, Pattern: strconv.Quote(pattern)})
+	}
+	sort.Slice(model.Patterns, func(i, j int) bool {
+		return model.Patterns[i].Name < model.Patterns[j].Name
+	})
+
+	if b.nested {
+		model.SharedPackage = sharedTypesPackage
+	}
+
+	for importPath := range b.imports {
+		model.Imports = append(model.Imports, importPath)
+	}
+	sort.Strings(model.Imports)
+
+	return model, nil
+}
+
+// validatedTypes returns the names of the types that have a generated Validate method
+func validatedTypes(types TypeDefinitions) map[string]bool {
+	result := make(map[string]bool)
+	for _, structType := range types.Structs {
+		result[structType.Name] = true
+	}
+	for _, unionType := range types.Unions {
+		result[unionType.Name] = true
+	}
+	return result
+}
+
+// resolve follows type aliases to the underlying Go type
+func (b *validationBuilder) resolve(goType string) string {
+	for i := 0; i < 10; i++ {
+		target, ok := b.aliases[goType]
+		if !ok {
+			break
+		}
+		goType = target
+	}
+	return goType
+}
+
+// kind classifies a Go type for validation purposes, resolving type aliases
+func (b *validationBuilder) kind(goType string) string {
+	goType = b.resolve(goType)
+	switch {
+	case b.structs[goType]:
+		return "struct"
+	case b.unions[goType]:
+		return "union"
+	case b.enums[goType] != nil:
+		return "enum"
+	case strings.HasPrefix(goType, "[]"):
+		return "slice"
+	case strings.HasPrefix(goType, "map["):
+		return "map"
+	case goType == "string":
+		return "string"
+	case goType == "int" || goType == "int32" || goType == "int64" || goType == "float32" || goType == "float64":
+		return "number"
+	default:
+		return "other"
+	}
+}
+
+// patternVar returns the name of the package-level regular expression for the given pattern
+func (b *validationBuilder) patternVar(pattern string) string {
+	if name, ok := b.patterns[pattern]; ok {
+		return name
+	}
+	name := fmt.Sprintf("validationPattern%d", len(b.patterns))
+	b.patterns[pattern] = name
+	b.imports["regexp"] = true
+	return name
+}
+
+// fieldStatements renders the validation statements for a single struct field
+func (b *validationBuilder) fieldStatements(field Field) []string {
+	if field.Embedded {
+		if b.kind(field.Type) == "struct" {
+			return []string{"\t" + b.nestedCall(field.Type, "v."+field.Name, "path")}
+		}
+		return nil
+	}
+
+	jsonName := strings.Split(field.JSONTag, ",")[0]
+	fieldPath := fmt.Sprintf("path+%q", jsonName)
+	access := "v." + field.Name
+	goType := field.Type
+
+	// Pointer fields are only validated when set
+	if strings.HasPrefix(goType, "*") {
+		goType = strings.TrimPrefix(goType, "*")
+		value := "*" + access
+		switch b.kind(goType) {
+		case "struct", "union":
+			value = access
+		case "enum":
+			value = "(*" + access + ")"
+		}
+		checks := b.valueChecks(value, goType, fieldPath, field.Constraints)
+		if len(checks) == 0 {
+			return nil
+		}
+		return wrapStatements(fmt.Sprintf("if %s != nil {", access), checks)
+	}
+
+	kind := b.kind(goType)
+	checks := b.valueChecks(access, goType, fieldPath, field.Constraints)
+
+	switch kind {
+	case "slice", "map":
+		if field.Optional || field.Nullable {
+			if len(checks) == 0 {
+				return nil
+			}
+			return wrapStatements(fmt.Sprintf("if %s != nil {", access), checks)
+		}
+		// Required collections must be present
+		return append([]string{
+			fmt.Sprintf("\tif %s == nil {", access),
+			fmt.Sprintf("\t\terrs.add(%s, \"is required\")", fieldPath),
+			"\t}",
+		}, checks...)
+	case "union":
+		if field.Optional || field.Nullable {
+			return wrapStatements(fmt.Sprintf("if %s.Value != nil {", access), checks)
+		}
+		return append([]string{
+			fmt.Sprintf("\tif %s.Value == nil {", access),
+			fmt.Sprintf("\t\terrs.add(%s, \"is required\")", fieldPath),
+			"\t}",
+		}, checks...)
+	case "struct":
+		// Absent optional structs cannot be told apart from zero values, so only required ones are validated
+		if field.Optional || field.Nullable {
+			return nil
+		}
+		return checks
+	case "string", "enum":
+		if (field.Optional || field.Nullable) && len(checks) > 0 {
+			return wrapStatements(fmt.Sprintf("if %s != \"\" {", access), checks)
+		}
+		return checks
+	case "number":
+		if (field.Optional || field.Nullable) && len(checks) > 0 {
+			return wrapStatements(fmt.Sprintf("if %s != 0 {", access), checks)
+		}
+		return checks
+	default:
+		return checks
+	}
+}
+
+// valueChecks renders the constraint checks for a value of the given Go type
+func (b *validationBuilder) valueChecks(value, goType, fieldPath string, constraints Constraints) []string {
+	var checks []string
+	add := func(condition, message string) {
+		checks = append(checks,
+			fmt.Sprintf("\tif %s {", condition),
+			fmt.Sprintf("\t\terrs.add(%s, %q)", fieldPath, message),
+			"\t}",
+		)
+	}
+
+	switch b.kind(goType) {
+	case "struct", "union":
+		checks = append(checks, "\t"+b.nestedCall(goType, value, fieldPath+"+\".\""))
+	case "enum":
+		add(fmt.Sprintf("!%s.IsValid()", value), "must be one of "+strings.Join(b.enums[b.resolve(goType)], ", "))
+	case "string":
+		if constraints.MinLength > 0 {
+			b.imports["unicode/utf8"] = true
+			add(fmt.Sprintf("utf8.RuneCountInString(string(%s)) < %d", value, constraints.MinLength),
+				fmt.Sprintf("must be at least %d characters long", constraints.MinLength))
+		}
+		if constraints.MaxLength != nil {
+			b.imports["unicode/utf8"] = true
+			add(fmt.Sprintf("utf8.RuneCountInString(string(%s)) > %d", value, *constraints.MaxLength),
+				fmt.Sprintf("must be at most %d characters long", *constraints.MaxLength))
+		}
+		if constraints.Pattern != "" {
+			add(fmt.Sprintf("!%s.MatchString(string(%s))", b.patternVar(constraints.Pattern), value),
+				fmt.Sprintf("must match pattern %s", constraints.Pattern))
+		}
+	case "number":
+		if constraints.Minimum != nil {
+			limit := formatNumber(*constraints.Minimum)
+			if constraints.ExclusiveMinimum {
+				add(fmt.Sprintf("float64(%s) <= %s", value, limit), "must be greater than "+limit)
+			} else {
+				add(fmt.Sprintf("float64(%s) < %s", value, limit), "must be greater than or equal to "+limit)
+			}
+		}
+		if constraints.Maximum != nil {
+			limit := formatNumber(*constraints.Maximum)
+			if constraints.ExclusiveMaximum {
+				add(fmt.Sprintf("float64(%s) >= %s", value, limit), "must be less than "+limit)
+			} else {
+				add(fmt.Sprintf("float64(%s) > %s", value, limit), "must be less than or equal to "+limit)
+			}
+		}
+		if len(constraints.Enum) > 0 {
+			checks = append(checks,
+				fmt.Sprintf("\tswitch %s {", value),
+				fmt.Sprintf("\tcase %s:", strings.Join(constraints.Enum, ", ")),
+				"\tdefault:",
+				fmt.Sprintf("\t\terrs.add(%s, %q)", fieldPath, "must be one of "+strings.Join(constraints.Enum, ", ")),
+				"\t}",
+			)
+		}
+	case "slice":
+		if constraints.MinItems > 0 {
+			add(fmt.Sprintf("len(%s) < %d", value, constraints.MinItems),
+				fmt.Sprintf("must contain at least %d items", constraints.MinItems))
+		}
+		if constraints.MaxItems != nil {
+			add(fmt.Sprintf("len(%s) > %d", value, *constraints.MaxItems),
+				fmt.Sprintf("must contain at most %d items", *constraints.MaxItems))
+		}
+
+		// Validate struct and union elements with their index in the path
+		elemType := strings.TrimPrefix(goType, "[]")
+		if elemKind := b.kind(elemType); elemKind == "struct" || elemKind == "union" {
+			b.imports["strconv"] = true
+			checks = append(checks,
+				fmt.Sprintf("\tfor i := range %s {", value),
+				"\t\t"+b.nestedCall(elemType, value+"[i]", fieldPath+"+\"[\"+strconv.Itoa(i)+\"].\""),
+				"\t}",
+			)
+		}
+	}
+
+	return checks
+}
+
+// nestedCall renders the validation of a nested struct or union value under the given path prefix
+func (b *validationBuilder) nestedCall(goType, value, prefix string) string {
+	if b.shared[b.resolve(goType)] {
+		b.nested = true
+		return fmt.Sprintf("errs.addNested(%s, %s.Validate())", prefix, value)
+	}
+	return fmt.Sprintf("%s.validate(%s, errs)", value, prefix)
+}
+
+// wrapStatements indents statements into a block opened by the given header
+func wrapStatements(header string, statements []string) []string {
+	wrapped := []string{"\t" + header}
+	for _, statement := range statements {
+		wrapped = append(wrapped, "\t"+statement)
+	}
+	return append(wrapped, "\t}")
+}
+
+// formatNumber renders a constraint limit as a Go constant
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
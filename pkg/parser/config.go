@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shogotsuneto/dapr-actor-gen/pkg/generator"
+)
+
+// actorConfigExtension is the vendor extension declaring the Dapr runtime settings of each actor.
+// It may be declared at the root of the spec or in its info object:
+//
+//	x-dapr-actor-config:
+//	  BankAccount:
+//	    idleTimeout: 1h
+//	    scanInterval: 30s
+//	    reentrancy:
+//	      enabled: true
+//	      maxStackDepth: 16
+const actorConfigExtension = "x-dapr-actor-config"
+
+// actorConfigDeclaration is the runtime configuration of one actor in the x-dapr-actor-config extension
+type actorConfigDeclaration struct {
+	IdleTimeout                string `json:"idleTimeout"`
+	ScanInterval               string `json:"scanInterval"`
+	DrainOngoingCallTimeout    string `json:"drainOngoingCallTimeout"`
+	DrainRebalancedActors      *bool  `json:"drainRebalancedActors"`
+	RemindersStoragePartitions int    `json:"remindersStoragePartitions"`
+	Serializer                 string `json:"serializer"`
+	Reentrancy                 *struct {
+		Enabled       bool `json:"enabled"`
+		MaxStackDepth int  `json:"maxStackDepth"`
+	} `json:"reentrancy"`
+}
+
+// parseActorConfig attaches the runtime settings declared in the x-dapr-actor-config extension to the actors
+func (p *OpenAPIParser) parseActorConfig(model *generator.GenerationModel) error {
+	declared := make(map[string]actorConfigDeclaration)
+	type location struct {
+		name       string
+		extensions map[string]any
+	}
+	locations := []location{{actorConfigExtension, p.doc.Extensions}}
+	if p.doc.Info != nil {
+		locations = append(locations, location{"info." + actorConfigExtension, p.doc.Info.Extensions})
+	}
+
+	for _, location := range locations {
+		raw, ok := location.extensions[actorConfigExtension]
+		if !ok {
+			continue
+		}
+
+		// Extension values are kept as decoded JSON; re-decode them strictly so that typos are reported
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return fmt.Errorf("invalid %s extension: %v", location.name, err)
+		}
+		var configs map[string]actorConfigDeclaration
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&configs); err != nil {
+			return fmt.Errorf("invalid %s extension: expected a map of actor types to runtime settings: %v", location.name, err)
+		}
+
+		for actorType, declaration := range configs {
+			if _, exists := declared[actorType]; exists {
+				return fmt.Errorf("runtime settings of %s are declared both at the root and in info", actorType)
+			}
+			declared[actorType] = declaration
+		}
+	}
+
+	actorTypes := make([]string, 0, len(declared))
+	for actorType := range declared {
+		actorTypes = append(actorTypes, actorType)
+	}
+	sort.Strings(actorTypes)
+
+	for _, actorType := range actorTypes {
+		actor := findActor(model, actorType)
+		if actor == nil {
+			return fmt.Errorf("%s declares settings for unknown actor type '%s'", actorConfigExtension, actorType)
+		}
+		config, err := buildRuntimeConfig(declared[actorType])
+		if err != nil {
+			return fmt.Errorf("%s of %s: %v", actorConfigExtension, actorType, err)
+		}
+		actor.Config = config
+	}
+
+	return nil
+}
+
+// buildRuntimeConfig validates the runtime settings of an actor
+func buildRuntimeConfig(declaration actorConfigDeclaration) (*generator.RuntimeConfig, error) {
+	durations := []struct {
+		name  string
+		value string
+	}{
+		{"idleTimeout", declaration.IdleTimeout},
+		{"scanInterval", declaration.ScanInterval},
+		{"drainOngoingCallTimeout", declaration.DrainOngoingCallTimeout},
+	}
+	for _, duration := range durations {
+		if duration.value == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(duration.value); err != nil || d <= 0 {
+			return nil, fmt.Errorf("%s '%s' must be a positive duration such as 30s or 1h", duration.name, duration.value)
+		}
+	}
+	if declaration.Serializer != "" && declaration.Serializer != "json" {
+		return nil, fmt.Errorf("serializer '%s' is not supported; the Dapr Go SDK only provides json", declaration.Serializer)
+	}
+	if declaration.RemindersStoragePartitions < 0 {
+		return nil, fmt.Errorf("remindersStoragePartitions must not be negative")
+	}
+
+	config := &generator.RuntimeConfig{
+		IdleTimeout:                declaration.IdleTimeout,
+		ScanInterval:               declaration.ScanInterval,
+		DrainOngoingCallTimeout:    declaration.DrainOngoingCallTimeout,
+		DrainRebalancedActors:      declaration.DrainRebalancedActors,
+		RemindersStoragePartitions: declaration.RemindersStoragePartitions,
+		Serializer:                 declaration.Serializer,
+	}
+	if declaration.Reentrancy != nil {
+		if declaration.Reentrancy.MaxStackDepth < 0 {
+			return nil, fmt.Errorf("reentrancy.maxStackDepth must not be negative")
+		}
+		config.ReentrancyEnabled = declaration.Reentrancy.Enabled
+		config.ReentrancyMaxStackDepth = declaration.Reentrancy.MaxStackDepth
+	}
+	return config, nil
+}
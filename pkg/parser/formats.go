@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shogotsuneto/dapr-actor-gen/pkg/generator"
+)
+
+// FormatMapping maps an OpenAPI schema format to a Go type
+type FormatMapping struct {
+	Type   string // Go type, qualified with its package name for external types (e.g. "decimal.Decimal")
+	Import string // Import path of the type's package, empty for builtin types
+}
+
+// DefaultFormatMappings returns the built-in mappings from OpenAPI formats to Go types.
+// Formats without a mapping fall back to the Go type of the schema's type. "date" stays a string:
+// time.Time would marshal a full timestamp instead of a calendar date.
+func DefaultFormatMappings() map[string]FormatMapping {
+	return map[string]FormatMapping{
+		"date-time": {Type: "time.Time", Import: "time"},
+		"uuid":      {Type: "uuid.UUID", Import: "github.com/google/uuid"},
+		"byte":      {Type: "[]byte"},
+		"binary":    {Type: "[]byte"},
+		"int64":     {Type: "int64"},
+	}
+}
+
+// ParseFormatMapping parses a format mapping in the form "format=type" or "format=importpath.Type"
+// e.g. "decimal=github.com/shopspring/decimal.Decimal" or "date=string"
+func ParseFormatMapping(value string) (string, FormatMapping, error) {
+	format, target, ok := strings.Cut(value, "=")
+	format = strings.TrimSpace(format)
+	target = strings.TrimSpace(target)
+	if !ok || format == "" || target == "" {
+		return "", FormatMapping{}, fmt.Errorf("invalid format mapping '%s': expected format=type or format=importpath.Type", value)
+	}
+
+	// Builtin types (e.g. "string", "int64", "[]byte") have no import path
+	lastDot := strings.LastIndex(target, ".")
+	if lastDot < 0 {
+		return format, FormatMapping{Type: target}, nil
+	}
+
+	importPath := target[:lastDot]
+	typeName := target[lastDot+1:]
+	if importPath == "" || typeName == "" {
+		return "", FormatMapping{}, fmt.Errorf("invalid format mapping '%s': expected format=importpath.Type", value)
+	}
+	return format, FormatMapping{
+		Type:   generator.ImportPathToAssumedName(importPath) + "." + typeName,
+		Import: importPath,
+	}, nil
+}
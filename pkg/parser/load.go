@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/yaml"
+)
+
+// jsonMediaType is the only media type the generator reads request and response schemas from
+const jsonMediaType = "application/json"
+
+// specVersion holds the version fields telling Swagger 2.0 and OpenAPI 3 documents apart
+type specVersion struct {
+	Swagger any    `json:"swagger"`
+	OpenAPI string `json:"openapi"`
+}
+
+// LoadSpec loads an OpenAPI 3.0, OpenAPI 3.1 or Swagger 2.0 specification file. Swagger 2.0 and OpenAPI 3.1
+// documents are converted to OpenAPI 3.0; the returned warnings describe the parts the conversion could not
+// carry over as-is.
+func LoadSpec(path string) (*openapi3.T, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read spec: %v", err)
+	}
+
+	var version specVersion
+	if err := yaml.Unmarshal(data, &version); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode spec: %v", err)
+	}
+	switch swagger := fmt.Sprint(version.Swagger); {
+	case version.Swagger != nil && swagger != "2.0" && swagger != "2":
+		// An unquoted 2.0 is decoded as the number 2
+		return nil, nil, fmt.Errorf("unsupported swagger version %v: only \"2.0\" can be converted", version.Swagger)
+	case version.Swagger != nil:
+		return convertSwagger(data, path)
+	case strings.HasPrefix(version.OpenAPI, "3.1."):
+		return loadOpenAPI31(data, path)
+	default:
+		doc, err := openapi3.NewLoader().LoadFromFile(path)
+		return doc, nil, err
+	}
+}
+
+// convertSwagger converts a Swagger 2.0 document to OpenAPI 3
+func convertSwagger(data []byte, path string) (*openapi3.T, []string, error) {
+	// An unquoted 2.0 is decoded as a number, which openapi2.T does not accept as its version
+	var document map[string]any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode Swagger 2.0 spec: %v", err)
+	}
+	document["swagger"] = "2.0"
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode Swagger 2.0 spec: %v", err)
+	}
+
+	var doc2 openapi2.T
+	if err := json.Unmarshal(data, &doc2); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode Swagger 2.0 spec: %v", err)
+	}
+
+	warnings := swaggerWarnings(&doc2)
+
+	// Without consumes the converted request bodies would accept any media type; actor invocations carry JSON
+	if len(doc2.Consumes) == 0 {
+		doc2.Consumes = []string{jsonMediaType}
+	}
+
+	location := &url.URL{Path: filepath.ToSlash(path)}
+	doc, err := openapi2conv.ToV3WithLoader(&doc2, openapi3.NewLoader(), location)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert Swagger 2.0 spec to OpenAPI 3: %v", err)
+	}
+	return doc, warnings, nil
+}
+
+// swaggerWarnings describes the parts of a Swagger 2.0 document that do not map to actor methods as-is
+func swaggerWarnings(doc *openapi2.T) []string {
+	var warnings []string
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		pathItem := doc.Paths[path]
+		operations := pathItem.Operations()
+		methods := make([]string, 0, len(operations))
+		for method := range operations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			operation := operations[method]
+			name := method + " " + path
+
+			var body bool
+			var formData []string
+			for _, parameter := range append(append(openapi2.Parameters{}, pathItem.Parameters...), operation.Parameters...) {
+				switch {
+				case parameter.In == "body":
+					body = true
+				case parameter.In == "formData":
+					formData = append(formData, parameter.Name)
+				case parameter.CollectionFormat != "":
+					warnings = append(warnings, fmt.Sprintf("%s: collectionFormat %s of parameter %s is not carried over",
+						name, parameter.CollectionFormat, parameter.Name))
+				}
+			}
+			if len(formData) > 0 {
+				warnings = append(warnings, fmt.Sprintf("%s: formData parameters %s are converted to the properties of an object request body",
+					name, strings.Join(formData, ", ")))
+			}
+
+			consumes := operation.Consumes
+			if len(consumes) == 0 {
+				consumes = doc.Consumes
+			}
+			if (body || len(formData) > 0) && len(consumes) > 0 && !contains(consumes, jsonMediaType) {
+				warnings = append(warnings, fmt.Sprintf("%s: request body is declared as %s, not %s; only JSON request bodies can be generated",
+					name, strings.Join(consumes, ", "), jsonMediaType))
+			}
+
+			// The conversion applies the operation's produces only; a document-wide produces is replaced by JSON
+			if len(operation.Produces) > 0 && !contains(operation.Produces, jsonMediaType) {
+				warnings = append(warnings, fmt.Sprintf("%s: responses are declared as %s, not %s; their schemas are ignored",
+					name, strings.Join(operation.Produces, ", "), jsonMediaType))
+			}
+		}
+	}
+
+	if len(doc.Produces) > 0 && !contains(doc.Produces, jsonMediaType) {
+		warnings = append(warnings, fmt.Sprintf("produces %s is not carried over: responses without their own produces are read as %s",
+			strings.Join(doc.Produces, ", "), jsonMediaType))
+	}
+	return warnings
+}
@@ -0,0 +1,433 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/yaml"
+)
+
+// unsupportedKeywords are the JSON Schema 2020-12 keywords without an OpenAPI 3.0 counterpart.
+// They are removed from the schemas and reported, as they cannot be reflected in the generated types.
+var unsupportedKeywords = []string{
+	"$dynamicAnchor", "$dynamicRef", "contains", "dependentRequired", "dependentSchemas", "else", "if",
+	"maxContains", "minContains", "patternProperties", "propertyNames", "then", "unevaluatedItems", "unevaluatedProperties",
+}
+
+// annotationKeywords are JSON Schema 2020-12 keywords that do not affect the generated code; they are removed silently
+var annotationKeywords = []string{"$anchor", "$comment", "$id", "$schema", "contentEncoding", "contentMediaType", "contentSchema"}
+
+// operationMethods are the keys of a path item holding operations
+var operationMethods = []string{"delete", "get", "head", "options", "patch", "post", "put", "trace"}
+
+// loadOpenAPI31 rewrites an OpenAPI 3.1 document into its OpenAPI 3.0 form before loading it:
+//
+//   - type arrays with "null" become nullable schemas: type: [string, "null"] -> type: string, nullable: true
+//   - const becomes a single-value enum
+//   - $defs are moved to components/schemas and the references to them are updated
+//   - numeric exclusiveMinimum/exclusiveMaximum become minimum/maximum with the boolean flag
+//   - the first of the examples becomes the example
+//
+// Keywords without an OpenAPI 3.0 counterpart are removed and reported as warnings.
+func loadOpenAPI31(data []byte, path string) (*openapi3.T, []string, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode OpenAPI 3.1 spec: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(jsonData, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode OpenAPI 3.1 spec: %v", err)
+	}
+
+	n := &schemaNormalizer{defs: make(map[string]any), refs: make(map[string]string)}
+	if err := n.normalizeDocument(doc); err != nil {
+		return nil, nil, err
+	}
+	doc["openapi"] = "3.0.3"
+
+	jsonData, err = json.Marshal(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode normalized OpenAPI 3.1 spec: %v", err)
+	}
+	location := &url.URL{Path: filepath.ToSlash(path)}
+	loaded, err := openapi3.NewLoader().LoadFromDataWithPath(jsonData, location)
+	if err != nil {
+		return nil, nil, err
+	}
+	return loaded, n.warnings, nil
+}
+
+// schemaNormalizer rewrites the JSON Schema 2020-12 constructs of the schemas of a document
+type schemaNormalizer struct {
+	// defs collects the $defs moved to components/schemas by name
+	defs map[string]any
+	// refs maps the JSON pointers of moved $defs to their new component schema references
+	refs     map[string]string
+	warnings []string
+	// err is the first error found while normalizing
+	err error
+}
+
+// normalizeDocument normalizes every schema of the document: component schemas, parameters, request and
+// response bodies, headers, and the schemas of the x-dapr-actor-state, x-dapr-reminders and x-dapr-timers extensions
+func (n *schemaNormalizer) normalizeDocument(doc map[string]any) error {
+	// $defs at the document root are referenced as #/$defs/<Name>
+	if defs, ok := doc["$defs"].(map[string]any); ok {
+		delete(doc, "$defs")
+		n.hoistDefs("#", defs)
+	}
+
+	components, _ := doc["components"].(map[string]any)
+	schemas, _ := components["schemas"].(map[string]any)
+	for _, name := range sortedMapKeys(schemas) {
+		n.normalizeSchema("#/components/schemas/"+escapePointer(name), schemas[name])
+	}
+	for _, section := range []string{"parameters", "headers"} {
+		items, _ := components[section].(map[string]any)
+		for _, name := range sortedMapKeys(items) {
+			n.normalizeParameter("#/components/"+section+"/"+escapePointer(name), items[name])
+		}
+	}
+	for _, section := range []string{"requestBodies", "responses"} {
+		items, _ := components[section].(map[string]any)
+		for _, name := range sortedMapKeys(items) {
+			n.normalizeBody("#/components/"+section+"/"+escapePointer(name), items[name])
+		}
+	}
+
+	paths, _ := doc["paths"].(map[string]any)
+	for _, path := range sortedMapKeys(paths) {
+		pathItem, _ := paths[path].(map[string]any)
+		pointer := "#/paths/" + escapePointer(path)
+		n.normalizeParameters(pointer+"/parameters", pathItem["parameters"])
+		for _, method := range operationMethods {
+			operation, ok := pathItem[method].(map[string]any)
+			if !ok {
+				continue
+			}
+			n.normalizeParameters(pointer+"/"+method+"/parameters", operation["parameters"])
+			n.normalizeBody(pointer+"/"+method+"/requestBody", operation["requestBody"])
+			responses, _ := operation["responses"].(map[string]any)
+			for _, status := range sortedMapKeys(responses) {
+				n.normalizeBody(pointer+"/"+method+"/responses/"+status, responses[status])
+			}
+		}
+	}
+
+	if state, ok := doc[actorStateExtension].(map[string]any); ok {
+		for _, actorType := range sortedMapKeys(state) {
+			keys, _ := state[actorType].(map[string]any)
+			for _, key := range sortedMapKeys(keys) {
+				n.normalizeSchema("#/"+actorStateExtension+"/"+escapePointer(actorType)+"/"+escapePointer(key), keys[key])
+			}
+		}
+	}
+	for _, extension := range []string{remindersExtension, timersExtension} {
+		declared, _ := doc[extension].(map[string]any)
+		for _, actorType := range sortedMapKeys(declared) {
+			declarations, _ := declared[actorType].([]any)
+			for i, declaration := range declarations {
+				if declaration, ok := declaration.(map[string]any); ok {
+					n.normalizeSchema(fmt.Sprintf("#/%s/%s/%d/payload", extension, escapePointer(actorType), i), declaration["payload"])
+				}
+			}
+		}
+	}
+
+	if n.err != nil {
+		return n.err
+	}
+	if len(n.defs) > 0 {
+		if components == nil {
+			components = make(map[string]any)
+			doc["components"] = components
+		}
+		if schemas == nil {
+			schemas = make(map[string]any)
+			components["schemas"] = schemas
+		}
+		for _, name := range sortedMapKeys(n.defs) {
+			if _, exists := schemas[name]; exists {
+				return fmt.Errorf("$defs schema %s conflicts with the component schema of the same name", name)
+			}
+			schemas[name] = n.defs[name]
+		}
+		rewriteRefs(doc, n.refs)
+	}
+	return nil
+}
+
+// normalizeParameters normalizes the schemas of a list of parameters
+func (n *schemaNormalizer) normalizeParameters(pointer string, value any) {
+	parameters, _ := value.([]any)
+	for i, parameter := range parameters {
+		n.normalizeParameter(fmt.Sprintf("%s/%d", pointer, i), parameter)
+	}
+}
+
+// normalizeParameter normalizes the schema of a parameter or header
+func (n *schemaNormalizer) normalizeParameter(pointer string, value any) {
+	parameter, ok := value.(map[string]any)
+	if !ok {
+		return
+	}
+	n.normalizeSchema(pointer+"/schema", parameter["schema"])
+	n.normalizeContent(pointer+"/content", parameter["content"])
+}
+
+// normalizeBody normalizes the content and header schemas of a request body or response
+func (n *schemaNormalizer) normalizeBody(pointer string, value any) {
+	body, ok := value.(map[string]any)
+	if !ok {
+		return
+	}
+	n.normalizeContent(pointer+"/content", body["content"])
+	headers, _ := body["headers"].(map[string]any)
+	for _, name := range sortedMapKeys(headers) {
+		n.normalizeParameter(pointer+"/headers/"+escapePointer(name), headers[name])
+	}
+}
+
+// normalizeContent normalizes the schemas of the media types of a content map
+func (n *schemaNormalizer) normalizeContent(pointer string, value any) {
+	content, _ := value.(map[string]any)
+	for _, mediaType := range sortedMapKeys(content) {
+		if media, ok := content[mediaType].(map[string]any); ok {
+			n.normalizeSchema(pointer+"/"+escapePointer(mediaType)+"/schema", media["schema"])
+		}
+	}
+}
+
+// normalizeSchema rewrites a schema and its subschemas in place
+func (n *schemaNormalizer) normalizeSchema(pointer string, value any) {
+	schema, ok := value.(map[string]any)
+	if !ok {
+		return
+	}
+
+	if defs, ok := schema["$defs"].(map[string]any); ok {
+		delete(schema, "$defs")
+		n.hoistDefs(pointer, defs)
+	}
+
+	normalizeNullMembers(schema)
+	n.normalizeType(pointer, schema)
+
+	if value, ok := schema["const"]; ok {
+		delete(schema, "const")
+		if _, hasEnum := schema["enum"]; !hasEnum {
+			schema["enum"] = []any{value}
+		}
+		if _, hasType := schema["type"]; !hasType {
+			if typ := jsonType(value); typ != "" {
+				schema["type"] = typ
+			}
+		}
+	}
+
+	for _, bound := range []struct{ exclusive, inclusive string }{{"exclusiveMinimum", "minimum"}, {"exclusiveMaximum", "maximum"}} {
+		if limit, ok := schema[bound.exclusive].(float64); ok {
+			schema[bound.inclusive] = limit
+			schema[bound.exclusive] = true
+		}
+	}
+
+	if examples, ok := schema["examples"].([]any); ok {
+		delete(schema, "examples")
+		if _, hasExample := schema["example"]; !hasExample && len(examples) > 0 {
+			schema["example"] = examples[0]
+		}
+	}
+
+	if _, ok := schema["prefixItems"]; ok {
+		delete(schema, "prefixItems")
+		delete(schema, "items")
+		n.warn(pointer, "prefixItems is not supported; the tuple is generated as an array of interface{}")
+	}
+
+	for _, keyword := range annotationKeywords {
+		delete(schema, keyword)
+	}
+	for _, keyword := range unsupportedKeywords {
+		if _, ok := schema[keyword]; ok {
+			delete(schema, keyword)
+			n.warn(pointer, keyword+" is not supported and is ignored")
+		}
+	}
+
+	// Subschemas
+	properties, _ := schema["properties"].(map[string]any)
+	for _, name := range sortedMapKeys(properties) {
+		n.normalizeSchema(pointer+"/properties/"+escapePointer(name), properties[name])
+	}
+	for _, keyword := range []string{"items", "additionalProperties", "not"} {
+		n.normalizeSchema(pointer+"/"+keyword, schema[keyword])
+	}
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		members, _ := schema[keyword].([]any)
+		for i, member := range members {
+			n.normalizeSchema(fmt.Sprintf("%s/%s/%d", pointer, keyword, i), member)
+		}
+	}
+}
+
+// normalizeType maps type arrays to a single type. A "null" member makes the schema nullable;
+// schemas with several other types cannot be typed and are left without a type.
+func (n *schemaNormalizer) normalizeType(pointer string, schema map[string]any) {
+	var types []string
+	switch typ := schema["type"].(type) {
+	case string:
+		types = []string{typ}
+	case []any:
+		for _, t := range typ {
+			if t, ok := t.(string); ok {
+				types = append(types, t)
+			}
+		}
+	default:
+		return
+	}
+
+	var nonNull []string
+	for _, t := range types {
+		if t == "null" {
+			schema["nullable"] = true
+		} else {
+			nonNull = append(nonNull, t)
+		}
+	}
+
+	switch len(nonNull) {
+	case 1:
+		schema["type"] = nonNull[0]
+	case 0:
+		delete(schema, "type")
+	default:
+		delete(schema, "type")
+		n.warn(pointer, fmt.Sprintf("type [%s] is not supported; the value is generated as interface{}", strings.Join(nonNull, ", ")))
+	}
+
+	// null is expressed by nullable; it is not a value of an enum type
+	if enum, ok := schema["enum"].([]any); ok && schema["nullable"] == true {
+		values := enum[:0]
+		for _, value := range enum {
+			if value != nil {
+				values = append(values, value)
+			}
+		}
+		schema["enum"] = values
+	}
+}
+
+// normalizeNullMembers maps {type: "null"} members of anyOf and oneOf to nullable. A single remaining
+// member replaces the composition: a $ref becomes the schema's $ref, an inline schema is merged into it.
+func normalizeNullMembers(schema map[string]any) {
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		members, ok := schema[keyword].([]any)
+		if !ok {
+			continue
+		}
+		var nonNull []any
+		for _, member := range members {
+			if member, ok := member.(map[string]any); ok && member["type"] == "null" && len(member) == 1 {
+				schema["nullable"] = true
+				continue
+			}
+			nonNull = append(nonNull, member)
+		}
+		if len(nonNull) == len(members) {
+			continue
+		}
+
+		schema[keyword] = nonNull
+		switch len(nonNull) {
+		case 0:
+			delete(schema, keyword)
+		case 1:
+			if member, ok := nonNull[0].(map[string]any); ok {
+				delete(schema, keyword)
+				for key, value := range member {
+					if _, exists := schema[key]; !exists {
+						schema[key] = value
+					}
+				}
+			}
+		}
+	}
+}
+
+// hoistDefs moves the $defs of the schema at pointer to the component schemas
+func (n *schemaNormalizer) hoistDefs(pointer string, defs map[string]any) {
+	for _, name := range sortedMapKeys(defs) {
+		defPointer := pointer + "/$defs/" + escapePointer(name)
+		if _, exists := n.defs[name]; exists && n.err == nil {
+			n.err = fmt.Errorf("$defs schema %s is declared more than once", name)
+		}
+		n.refs[defPointer] = "#/components/schemas/" + escapePointer(name)
+		n.defs[name] = defs[name]
+		n.normalizeSchema(defPointer, defs[name])
+	}
+}
+
+// warn records a warning about the schema at pointer
+func (n *schemaNormalizer) warn(pointer, message string) {
+	n.warnings = append(n.warnings, pointer+": "+message)
+}
+
+// rewriteRefs replaces the $ref values found in refs throughout a decoded document
+func rewriteRefs(value any, refs map[string]string) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, item := range value {
+			if ref, ok := item.(string); ok && key == "$ref" {
+				if target, ok := refs[ref]; ok {
+					value[key] = target
+				}
+				continue
+			}
+			rewriteRefs(item, refs)
+		}
+	case []any:
+		for _, item := range value {
+			rewriteRefs(item, refs)
+		}
+	}
+}
+
+// jsonType returns the JSON Schema type of a decoded const value, or "" if it has none
+func jsonType(value any) string {
+	switch value := value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if value == math.Trunc(value) {
+			return "integer"
+		}
+		return "number"
+	default:
+		return ""
+	}
+}
+
+// escapePointer escapes a name for use as a JSON pointer token
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+// sortedMapKeys returns the keys of a decoded JSON object in ascending order
+func sortedMapKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -3,6 +3,7 @@ package parser
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -13,11 +14,40 @@ import (
 // OpenAPIParser handles conversion from OpenAPI specification to intermediate model
 type OpenAPIParser struct {
 	doc *openapi3.T
+	// inlineTypes collects types synthesized for inline request/response schemas while parsing actors
+	inlineTypes generator.TypeDefinitions
+	// formatMappings maps OpenAPI formats to Go types
+	formatMappings map[string]FormatMapping
+	// imports collects the import paths of external types used by format mappings (package qualifier -> path)
+	imports map[string]string
 }
 
 // NewOpenAPIParser creates a new OpenAPI parser
 func NewOpenAPIParser(doc *openapi3.T) *OpenAPIParser {
-	return &OpenAPIParser{doc: doc}
+	return &OpenAPIParser{
+		doc:            doc,
+		formatMappings: DefaultFormatMappings(),
+		imports:        make(map[string]string),
+	}
+}
+
+// WithFormatMappings adds or overrides format to Go type mappings
+func (p *OpenAPIParser) WithFormatMappings(mappings map[string]FormatMapping) *OpenAPIParser {
+	for format, mapping := range mappings {
+		p.formatMappings[format] = mapping
+	}
+	return p
+}
+
+// useFormatMapping records the import needed by a format mapping's Go type
+func (p *OpenAPIParser) useFormatMapping(mapping FormatMapping) {
+	if mapping.Import == "" {
+		return
+	}
+	qualifier := strings.TrimLeft(mapping.Type, "*[]")
+	if dot := strings.Index(qualifier, "."); dot > 0 {
+		p.imports[qualifier[:dot]] = mapping.Import
+	}
 }
 
 // Parse converts the OpenAPI specification to an intermediate generator.GenerationModel
@@ -29,11 +59,29 @@ func (p *OpenAPIParser) Parse() (*generator.GenerationModel, error) {
 		return nil, fmt.Errorf("failed to parse actors: %v", err)
 	}
 
+	// Attach the typed actor state declared in the spec before types are categorized
+	if err := p.parseActorState(model); err != nil {
+		return nil, fmt.Errorf("failed to parse actor state: %v", err)
+	}
+
+	// Attach the reminders and timers declared in the spec
+	if err := p.parseActorReminders(model); err != nil {
+		return nil, fmt.Errorf("failed to parse actor reminders: %v", err)
+	}
+
+	// Attach the runtime settings declared in the spec
+	if err := p.parseActorConfig(model); err != nil {
+		return nil, fmt.Errorf("failed to parse actor runtime config: %v", err)
+	}
+
 	// Parse types and assign them to actors that use them
 	if err := p.parseAndCategorizeTypes(model); err != nil {
 		return nil, fmt.Errorf("failed to parse and categorize types: %v", err)
 	}
 
+	// Record the imports required by external types from format mappings
+	model.Imports = p.imports
+
 	return model, nil
 }
 
@@ -45,6 +93,11 @@ func (p *OpenAPIParser) parseAndCategorizeTypes(model *generator.GenerationModel
 		return err
 	}
 
+	// Error types wrapping response bodies must not clash with declared types
+	if err := checkErrorTypeNames(model, allTypes); err != nil {
+		return err
+	}
+
 	// Sort all types for consistent ordering
 	p.sortTypes(&allTypes)
 
@@ -57,12 +110,19 @@ func (p *OpenAPIParser) parseTypes() (generator.TypeDefinitions, error) {
 	var allStructs []generator.StructType
 	var allAliases []generator.TypeAlias
 	var allEnums []generator.EnumType
+	var allUnions []generator.UnionType
+
+	// Include types synthesized for inline request/response schemas
+	allStructs = append(allStructs, p.inlineTypes.Structs...)
+	allEnums = append(allEnums, p.inlineTypes.Enums...)
+	allUnions = append(allUnions, p.inlineTypes.Unions...)
 
 	if p.doc.Components == nil || p.doc.Components.Schemas == nil {
 		return generator.TypeDefinitions{
 			Structs: allStructs,
 			Aliases: allAliases,
 			Enums:   allEnums,
+			Unions:  allUnions,
 		}, nil
 	}
 
@@ -73,7 +133,7 @@ func (p *OpenAPIParser) parseTypes() (generator.TypeDefinitions, error) {
 		// Check if this is an enum type
 		if schema.Enum != nil && len(schema.Enum) > 0 {
 			// This is an enum type
-			baseType := getGoType(schema)
+			baseType := p.getGoType(schema)
 			var enumValues []string
 			for _, enumValue := range schema.Enum {
 				if str, ok := enumValue.(string); ok {
@@ -91,90 +151,28 @@ func (p *OpenAPIParser) parseTypes() (generator.TypeDefinitions, error) {
 			}
 		}
 
-		// Check if this should be a type alias (simple type without properties or with only basic properties)
-		if !schema.Type.Is("object") || schema.Properties == nil || len(schema.Properties) == 0 {
-			// This should be a type alias
-			goType := getGoType(schema)
+		// Check if this is a discriminated union (oneOf/anyOf with discriminator)
+		if unionType, ok := parseUnionType(name, schema); ok {
+			allUnions = append(allUnions, unionType)
+			continue
+		}
+
+		// Check if this should be a struct type (object with properties or allOf composition)
+		if isStructSchema(schema) {
+			structType, nested := p.parseStructType(name, schema)
+			allStructs = append(allStructs, structType)
+			allStructs = append(allStructs, nested.Structs...)
+			allEnums = append(allEnums, nested.Enums...)
+			allUnions = append(allUnions, nested.Unions...)
+		} else {
+			// This should be a type alias (simple type without properties)
+			goType := p.getGoType(schema)
 			allAliases = append(allAliases, generator.TypeAlias{
 				Name:         name,
 				Description:  schema.Description,
 				AliasTarget:  goType,
 				OriginalName: name,
 			})
-		} else if schema.Type.Is("object") && schema.Properties != nil {
-			// First pass: extract enum fields and create enum types
-			for propName, propRef := range schema.Properties {
-				prop := propRef.Value
-				if prop.Type.Is("string") && prop.Enum != nil && len(prop.Enum) > 0 {
-					// This is an enum field, create a separate enum type
-					enumTypeName := name + capitalizeFirst(propName)
-					var enumValues []string
-					for _, enumValue := range prop.Enum {
-						if str, ok := enumValue.(string); ok {
-							enumValues = append(enumValues, str)
-						}
-					}
-					if len(enumValues) > 0 {
-						allEnums = append(allEnums, generator.EnumType{
-							Name:        enumTypeName,
-							Description: fmt.Sprintf("defines valid values for %s.%s", name, propName),
-							BaseType:    "string",
-							Values:      enumValues,
-						})
-					}
-				}
-			}
-
-			// Second pass: generate struct type with proper field types
-			fields := []generator.Field{}
-
-			for propName, propRef := range schema.Properties {
-				prop := propRef.Value
-
-				// Check if this property is a reference to another schema
-				var goType string
-				if propRef.Ref != "" {
-					// Extract referenced type name from $ref
-					refParts := strings.Split(propRef.Ref, "/")
-					if len(refParts) > 0 {
-						goType = refParts[len(refParts)-1]
-					} else {
-						goType = getGoType(prop)
-					}
-				} else {
-					// Handle special case for arrays with referenced items
-					if prop.Type.Is("array") && prop.Items != nil && prop.Items.Ref != "" {
-						// Extract referenced type name from items.$ref
-						refParts := strings.Split(prop.Items.Ref, "/")
-						if len(refParts) > 0 {
-							goType = "[]" + refParts[len(refParts)-1]
-						} else {
-							goType = getGoType(prop)
-						}
-					} else if prop.Type.Is("string") && prop.Enum != nil && len(prop.Enum) > 0 {
-						// This is an enum field, use the generated enum type
-						goType = name + capitalizeFirst(propName)
-					} else {
-						goType = getGoType(prop)
-					}
-				}
-
-				jsonTag := propName
-				if !contains(schema.Required, propName) {
-					jsonTag += ",omitempty"
-				}
-				fields = append(fields, generator.Field{
-					Name:    capitalizeFirst(propName),
-					Type:    goType,
-					JSONTag: jsonTag,
-					Comment: prop.Description,
-				})
-			}
-			allStructs = append(allStructs, generator.StructType{
-				Name:        name,
-				Description: schema.Description,
-				Fields:      fields,
-			})
 		}
 	}
 
@@ -214,9 +212,226 @@ func (p *OpenAPIParser) parseTypes() (generator.TypeDefinitions, error) {
 		Structs: allStructs,
 		Aliases: allAliases,
 		Enums:   allEnums,
+		Unions:  allUnions,
 	}, nil
 }
 
+// structMembers collects the properties of an object schema, including those inherited through allOf
+type structMembers struct {
+	description string
+	properties  openapi3.Schemas
+	required    []string
+	embedded    []string
+}
+
+// collectStructMembers gathers properties, required lists and descriptions from a schema and its allOf parts.
+// Referenced parts that are themselves structs are embedded; inline parts are flattened into the struct.
+func (p *OpenAPIParser) collectStructMembers(schema *openapi3.Schema, members *structMembers) {
+	if members.description == "" {
+		members.description = schema.Description
+	}
+
+	for _, part := range schema.AllOf {
+		if part.Value == nil {
+			continue
+		}
+		if part.Ref != "" && isStructSchema(part.Value) {
+			baseType := refTypeName(part.Ref)
+			if !contains(members.embedded, baseType) {
+				members.embedded = append(members.embedded, baseType)
+			}
+			continue
+		}
+		p.collectStructMembers(part.Value, members)
+	}
+
+	for propName, propRef := range schema.Properties {
+		members.properties[propName] = propRef
+	}
+	members.required = append(members.required, schema.Required...)
+}
+
+// parseStructType builds a struct type from an object schema, along with the nested types
+// (structs, enums and unions) synthesized for its inline properties
+func (p *OpenAPIParser) parseStructType(name string, schema *openapi3.Schema) (generator.StructType, generator.TypeDefinitions) {
+	members := structMembers{properties: openapi3.Schemas{}}
+	p.collectStructMembers(schema, &members)
+
+	var nested generator.TypeDefinitions
+
+	// First pass: extract enum fields and create enum types
+	for propName, propRef := range members.properties {
+		prop := propRef.Value
+		if propRef.Ref == "" && prop.Type.Is("string") && prop.Enum != nil && len(prop.Enum) > 0 {
+			// This is an enum field, create a separate enum type
+			enumTypeName := name + capitalizeFirst(propName)
+			var enumValues []string
+			for _, enumValue := range prop.Enum {
+				if str, ok := enumValue.(string); ok {
+					enumValues = append(enumValues, str)
+				}
+			}
+			if len(enumValues) > 0 {
+				nested.Enums = append(nested.Enums, generator.EnumType{
+					Name:        enumTypeName,
+					Description: fmt.Sprintf("defines valid values for %s.%s", name, propName),
+					BaseType:    "string",
+					Values:      enumValues,
+				})
+			}
+		}
+	}
+
+	// Second pass: generate struct type with proper field types
+	fields := []generator.Field{}
+
+	// Embedded base types from allOf references
+	for _, baseType := range members.embedded {
+		fields = append(fields, generator.Field{
+			Name:     baseType,
+			Type:     baseType,
+			Comment:  fmt.Sprintf("embeds the properties of %s", baseType),
+			Embedded: true,
+		})
+	}
+
+	for propName, propRef := range members.properties {
+		prop := propRef.Value
+
+		// Check if this property is a reference to another schema
+		var goType string
+		if propRef.Ref != "" {
+			// Extract referenced type name from $ref
+			goType = refTypeName(propRef.Ref)
+		} else if prop.Type.Is("string") && prop.Enum != nil && len(prop.Enum) > 0 {
+			// This is an enum field, use the generated enum type
+			goType = name + capitalizeFirst(propName)
+		} else if unionType, ok := parseUnionType(name+capitalizeFirst(propName), prop); ok {
+			// This is an inline discriminated union, use the generated union type
+			nested.Unions = append(nested.Unions, unionType)
+			goType = unionType.Name
+		} else {
+			// Inline objects (also as array items) become named nested structs
+			goType = p.resolveGoType(propRef, name+capitalizeFirst(propName), &nested)
+		}
+
+		optional := !contains(members.required, propName)
+		jsonTag := propName
+		if optional {
+			jsonTag += ",omitempty"
+		}
+		fields = append(fields, generator.Field{
+			Name:        capitalizeFirst(propName),
+			Type:        goType,
+			JSONTag:     jsonTag,
+			Comment:     prop.Description,
+			Optional:    optional,
+			Nullable:    prop.Nullable,
+			Constraints: extractConstraints(prop),
+		})
+	}
+
+	return generator.StructType{
+		Name:        name,
+		Description: members.description,
+		Fields:      fields,
+	}, nested
+}
+
+// resolveGoType returns the Go type for a schema, synthesizing a struct named typeName for inline
+// object schemas. Synthesized types are appended to nested.
+func (p *OpenAPIParser) resolveGoType(schemaRef *openapi3.SchemaRef, typeName string, nested *generator.TypeDefinitions) string {
+	if schemaRef.Ref != "" {
+		return refTypeName(schemaRef.Ref)
+	}
+	schema := schemaRef.Value
+	if schema == nil {
+		return "interface{}"
+	}
+
+	switch {
+	case isStructSchema(schema):
+		structType, structNested := p.parseStructType(typeName, schema)
+		nested.Structs = append(nested.Structs, structType)
+		nested.Structs = append(nested.Structs, structNested.Structs...)
+		nested.Enums = append(nested.Enums, structNested.Enums...)
+		nested.Unions = append(nested.Unions, structNested.Unions...)
+		return typeName
+	case schema.Type.Is("array") && schema.Items != nil:
+		return "[]" + p.resolveGoType(schema.Items, typeName+"Item", nested)
+	case schema.Type.Is("object") && len(schema.Properties) == 0 && schema.AdditionalProperties.Schema != nil:
+		return "map[string]" + p.resolveGoType(schema.AdditionalProperties.Schema, typeName+"Value", nested)
+	case schema.Type.Is("object") && len(schema.Properties) == 0 && schema.AdditionalProperties.Has != nil && *schema.AdditionalProperties.Has:
+		return "map[string]interface{}"
+	default:
+		return p.getGoType(schema)
+	}
+}
+
+// resolveInlineType synthesizes named types for an inline request/response schema of an actor method.
+// The base name is prefixed with the actor type if it collides with an existing type.
+func (p *OpenAPIParser) resolveInlineType(actorType, baseName string, schemaRef *openapi3.SchemaRef) string {
+	typeName := baseName
+	if p.isTypeNameTaken(typeName) {
+		typeName = actorType + baseName
+	}
+	return p.resolveGoType(schemaRef, typeName, &p.inlineTypes)
+}
+
+// isTypeNameTaken checks if a type name is already used by a component schema or a synthesized inline type
+func (p *OpenAPIParser) isTypeNameTaken(typeName string) bool {
+	if p.doc.Components != nil {
+		if _, exists := p.doc.Components.Schemas[typeName]; exists {
+			return true
+		}
+	}
+	return p.isCustomTypeInDefinitions(typeName, p.inlineTypes)
+}
+
+// parseUnionType builds a discriminated union type from a oneOf/anyOf schema with a discriminator.
+// Every variant must be a $ref to a component schema; the discriminator value of a variant is taken
+// from the discriminator mapping, or defaults to the referenced schema name.
+func parseUnionType(name string, schema *openapi3.Schema) (generator.UnionType, bool) {
+	variantRefs := schema.OneOf
+	if len(variantRefs) == 0 {
+		variantRefs = schema.AnyOf
+	}
+	if len(variantRefs) == 0 || schema.Discriminator == nil || schema.Discriminator.PropertyName == "" {
+		return generator.UnionType{}, false
+	}
+
+	unionType := generator.UnionType{
+		Name:          name,
+		Description:   schema.Description,
+		Discriminator: schema.Discriminator.PropertyName,
+	}
+
+	for _, variantRef := range variantRefs {
+		if variantRef.Ref == "" {
+			return generator.UnionType{}, false // inline variants cannot be named
+		}
+		typeName := refTypeName(variantRef.Ref)
+
+		var values []string
+		for value, target := range schema.Discriminator.Mapping {
+			if target == variantRef.Ref || target == typeName {
+				values = append(values, value)
+			}
+		}
+		if len(values) == 0 {
+			values = []string{typeName}
+		}
+		sort.Strings(values)
+
+		unionType.Variants = append(unionType.Variants, generator.UnionVariant{
+			TypeName: typeName,
+			Values:   values,
+		})
+	}
+
+	return unionType, true
+}
+
 // sortTypes handles all sorting logic for consistent ordering
 func (p *OpenAPIParser) sortTypes(types *generator.TypeDefinitions) {
 	// Sort all structs by name
@@ -224,10 +439,14 @@ func (p *OpenAPIParser) sortTypes(types *generator.TypeDefinitions) {
 		return types.Structs[i].Name < types.Structs[j].Name
 	})
 
-	// Sort fields within each struct by name
+	// Sort fields within each struct by name, keeping embedded types first
 	for i := range types.Structs {
-		sort.Slice(types.Structs[i].Fields, func(j, k int) bool {
-			return types.Structs[i].Fields[j].Name < types.Structs[i].Fields[k].Name
+		fields := types.Structs[i].Fields
+		sort.Slice(fields, func(j, k int) bool {
+			if fields[j].Embedded != fields[k].Embedded {
+				return fields[j].Embedded
+			}
+			return fields[j].Name < fields[k].Name
 		})
 	}
 
@@ -240,6 +459,11 @@ func (p *OpenAPIParser) sortTypes(types *generator.TypeDefinitions) {
 	sort.Slice(types.Enums, func(i, j int) bool {
 		return types.Enums[i].Name < types.Enums[j].Name
 	})
+
+	// Sort all unions by name
+	sort.Slice(types.Unions, func(i, j int) bool {
+		return types.Unions[i].Name < types.Unions[j].Name
+	})
 }
 
 // parseActors orchestrates the parsing, building, sorting, and creation of actor interfaces
@@ -313,7 +537,22 @@ func (p *OpenAPIParser) extractActorOperations() (map[string][]generator.ActorOp
 func (p *OpenAPIParser) buildActorMethods(actorOperations map[string][]generator.ActorOperation) (map[string][]generator.Method, error) {
 	actorMethods := make(map[string][]generator.Method)
 
-	for actorType, operations := range actorOperations {
+	// Process actors and operations in a stable order so synthesized inline type names are deterministic
+	actorTypes := make([]string, 0, len(actorOperations))
+	for actorType := range actorOperations {
+		actorTypes = append(actorTypes, actorType)
+	}
+	sort.Strings(actorTypes)
+
+	for _, actorType := range actorTypes {
+		operations := actorOperations[actorType]
+		sort.Slice(operations, func(i, j int) bool {
+			if operations[i].Path != operations[j].Path {
+				return operations[i].Path < operations[j].Path
+			}
+			return operations[i].HTTPMethod < operations[j].HTTPMethod
+		})
+
 		var methods []generator.Method
 
 		for _, operation := range operations {
@@ -387,23 +626,32 @@ func (p *OpenAPIParser) extractMethodFromOperation(op *openapi3.Operation, httpM
 		Name:       methodName,
 		Comment:    getOperationComment(op),
 		HasRequest: false,
-		ReturnType: "interface{}", // default return type
 	}
 
+	actorType := p.extractActorTypeFromPath(path)
+
 	// Check if operation has request body
 	if op.RequestBody != nil && op.RequestBody.Value != nil {
-		method.HasRequest = true
-		// Extract request type from schema
-		if requestType := extractRequestType(op.RequestBody.Value); requestType != "" {
-			method.RequestType = requestType
+		requestType, err := p.extractRequestType(op.RequestBody.Value, actorType, methodName)
+		if err != nil {
+			return nil, fmt.Errorf("request body of method %s: %v", methodName, err)
 		}
+		method.HasRequest = true
+		method.RequestType = requestType
 	}
 
-	// Extract return type from 200 response
-	if returnType := p.extractReturnType(op); returnType != "" {
-		method.ReturnType = returnType
+	// Extract return type from the success response; methods without a response body only return an error
+	if successSchema(op) != nil {
+		method.HasReturn = true
+		method.ReturnType = "interface{}" // default return type for bodies that are not mapped to a type
+		if returnType := p.extractReturnType(op, actorType, methodName); returnType != "" {
+			method.ReturnType = returnType
+		}
 	}
 
+	// Extract typed errors from documented non-2xx responses
+	method.Errors = p.extractErrorResponses(op, actorType, methodName)
+
 	return method, nil
 }
 
@@ -448,43 +696,146 @@ func (p *OpenAPIParser) extractActorTypeFromPath(path string) string {
 	return ""
 }
 
-// extractReturnType extracts the return type from 200 response
-func (p *OpenAPIParser) extractReturnType(op *openapi3.Operation) string {
-	if op.Responses == nil {
-		return ""
+// extractRequestType resolves the Go type of a request body with the same mapping as schema properties:
+// $refs, primitives, arrays and maps. Inline objects become a named <Method>Request struct.
+func (p *OpenAPIParser) extractRequestType(requestBody *openapi3.RequestBody, actorType, methodName string) (string, error) {
+	schemaRef := jsonSchemaRef(requestBody.Content)
+	if schemaRef == nil || (schemaRef.Ref == "" && schemaRef.Value == nil) {
+		return "", fmt.Errorf("only application/json request bodies with a schema are supported")
+	}
+
+	requestType := p.resolveInlineType(actorType, methodName+"Request", schemaRef)
+	if requestType == "interface{}" {
+		return "", fmt.Errorf("unsupported request body schema (resolves to %s); use a $ref, a primitive, an array, a map or an object with properties", requestType)
 	}
+	return requestType, nil
+}
 
-	// Look for 200 response
-	response200 := op.Responses.Status(200)
-	if response200 == nil || response200.Value == nil || response200.Value.Content == nil {
+// extractReturnType extracts the return type from the success response (see successSchema).
+// Like request bodies, inline object responses become a named <Method>Response struct, and primitive,
+// array and map bodies map to the corresponding Go types.
+func (p *OpenAPIParser) extractReturnType(op *openapi3.Operation, actorType, methodName string) string {
+	jsonSchema := successSchema(op)
+	if jsonSchema == nil {
 		return ""
 	}
+	return p.resolveInlineType(actorType, methodName+"Response", jsonSchema)
+}
 
-	// Look for JSON content
-	if jsonContent := response200.Value.Content.Get("application/json"); jsonContent != nil {
-		if jsonContent.Schema != nil {
-			// Handle direct $ref
-			if jsonContent.Schema.Ref != "" {
-				parts := strings.Split(jsonContent.Schema.Ref, "/")
-				if len(parts) > 0 {
-					return parts[len(parts)-1]
-				}
+// successSchema returns the JSON body schema of the first 2xx response that has one, checking explicit
+// status codes in ascending order before the 2XX range. It returns nil when no success response has a body.
+func successSchema(op *openapi3.Operation) *openapi3.SchemaRef {
+	if op.Responses == nil {
+		return nil
+	}
+
+	var statuses []string
+	for status := range op.Responses.Map() {
+		if code, err := strconv.Atoi(status); err == nil && code >= 200 && code < 300 {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses)
+	statuses = append(statuses, "2XX")
+
+	for _, status := range statuses {
+		responseRef := op.Responses.Value(status)
+		if responseRef == nil || responseRef.Value == nil {
+			continue
+		}
+		if schemaRef := jsonSchemaRef(responseRef.Value.Content); schemaRef != nil {
+			return schemaRef
+		}
+	}
+	return nil
+}
+
+// extractErrorResponses extracts the non-2xx responses (including default) that have a JSON body.
+// Each body type is wrapped in an error type named <Body>Error. Inline object bodies become a named
+// <Method><Status>Response struct.
+func (p *OpenAPIParser) extractErrorResponses(op *openapi3.Operation, actorType, methodName string) []generator.ErrorResponse {
+	if op.Responses == nil {
+		return nil
+	}
+
+	var errorResponses []generator.ErrorResponse
+	for status, responseRef := range op.Responses.Map() {
+		statusCode := 0
+		if status != "default" {
+			code, err := strconv.Atoi(status)
+			if err != nil || code < 300 {
+				continue // Success responses and status code ranges (e.g. 4XX) are not mapped
 			}
+			statusCode = code
+		}
+		if responseRef == nil || responseRef.Value == nil {
+			continue
+		}
+		schemaRef := jsonSchemaRef(responseRef.Value.Content)
+		if schemaRef == nil {
+			continue
+		}
 
-			schema := jsonContent.Schema.Value
-			if schema != nil {
-				// Handle array schemas with items.$ref
-				if schema.Type != nil && schema.Type.Is("array") && schema.Items != nil && schema.Items.Ref != "" {
-					parts := strings.Split(schema.Items.Ref, "/")
-					if len(parts) > 0 {
-						return "[]" + parts[len(parts)-1]
-					}
+		var bodyType string
+		switch {
+		case schemaRef.Ref != "":
+			bodyType = refTypeName(schemaRef.Ref)
+		case isInlineStructSchema(schemaRef):
+			bodyType = p.resolveInlineType(actorType, methodName+capitalizeFirst(status)+"Response", schemaRef)
+		default:
+			continue // Only object bodies are mapped to error types
+		}
+
+		description := ""
+		if responseRef.Value.Description != nil {
+			description = strings.TrimSpace(strings.SplitN(strings.TrimSpace(*responseRef.Value.Description), "\n", 2)[0])
+		}
+		errorResponses = append(errorResponses, generator.ErrorResponse{
+			StatusCode:  statusCode,
+			TypeName:    bodyType + "Error",
+			BodyType:    bodyType,
+			Description: description,
+		})
+	}
+
+	// Order by status code with the default response last
+	sort.Slice(errorResponses, func(i, j int) bool {
+		a, b := errorResponses[i].StatusCode, errorResponses[j].StatusCode
+		if a == 0 || b == 0 {
+			return b == 0 && a != 0
+		}
+		return a < b
+	})
+	return errorResponses
+}
+
+// checkErrorTypeNames reports error types whose <Body>Error name is already taken by a schema, e.g. a
+// Problem body next to a ProblemError schema, instead of silently generating conflicting declarations
+func checkErrorTypeNames(model *generator.GenerationModel, types generator.TypeDefinitions) error {
+	declared := make(map[string]bool)
+	for _, structType := range types.Structs {
+		declared[structType.Name] = true
+	}
+	for _, alias := range types.Aliases {
+		declared[alias.Name] = true
+	}
+	for _, enumType := range types.Enums {
+		declared[enumType.Name] = true
+	}
+	for _, unionType := range types.Unions {
+		declared[unionType.Name] = true
+	}
+	for _, actor := range model.Actors {
+		for _, method := range actor.Methods {
+			for _, response := range method.Errors {
+				if declared[response.TypeName] {
+					return fmt.Errorf("error type %s for the %s response body of %s.%s conflicts with the schema %s; rename the schema",
+						response.TypeName, response.BodyType, actor.ActorType, method.Name, response.TypeName)
 				}
 			}
 		}
 	}
-
-	return ""
+	return nil
 }
 
 // isCustomType checks if a type name refers to a custom type defined in the model
@@ -522,6 +873,13 @@ func (p *OpenAPIParser) isCustomTypeInDefinitions(typeName string, types generat
 		}
 	}
 
+	// Check if it's defined in our union types
+	for _, unionType := range types.Unions {
+		if unionType.Name == typeName {
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -541,26 +899,37 @@ func (p *OpenAPIParser) categorizeTypesIntoActors(model *generator.GenerationMod
 	for _, enumType := range allTypes.Enums {
 		typeUsage[enumType.Name] = make(map[string]bool)
 	}
+	for _, unionType := range allTypes.Unions {
+		typeUsage[unionType.Name] = make(map[string]bool)
+	}
 
 	// Analyze which actors use which types by examining request/response schemas
 	for _, actor := range model.Actors {
+		trackType := func(goType string) {
+			goType = generator.ElementTypeName(goType)
+			if _, exists := typeUsage[goType]; exists {
+				typeUsage[goType][actor.ActorType] = true
+			}
+		}
 		for _, method := range actor.Methods {
-			// Track request types
-			if method.HasRequest && method.RequestType != "" {
-				if _, exists := typeUsage[method.RequestType]; exists {
-					typeUsage[method.RequestType][actor.ActorType] = true
-				}
+			// Track request, return and error body types
+			if method.HasRequest {
+				trackType(method.RequestType)
 			}
-			// Track return types (remove pointer/slice prefixes for analysis)
-			returnType := method.ReturnType
-			returnType = strings.TrimPrefix(returnType, "*")
-			returnType = strings.TrimPrefix(returnType, "[]")
-			if returnType != "interface{}" && returnType != "" {
-				if _, exists := typeUsage[returnType]; exists {
-					typeUsage[returnType][actor.ActorType] = true
-				}
+			if method.HasReturn {
+				trackType(method.ReturnType)
+			}
+			for _, errorResponse := range method.Errors {
+				trackType(errorResponse.BodyType)
 			}
 		}
+		// Track types of the declared actor state and of reminder and timer payloads
+		for _, stateKey := range actor.State {
+			trackType(stateKey.Type)
+		}
+		for _, callback := range actor.Callbacks() {
+			trackType(callback.PayloadType)
+		}
 	}
 
 	// Also analyze type dependencies - if a type references another type,
@@ -568,10 +937,8 @@ func (p *OpenAPIParser) categorizeTypesIntoActors(model *generator.GenerationMod
 	typeDependencies := make(map[string][]string) // type -> []referenced_types
 	for _, structType := range allTypes.Structs {
 		for _, field := range structType.Fields {
-			// Extract referenced type from field type (handle arrays and pointers)
-			fieldType := field.Type
-			fieldType = strings.TrimPrefix(fieldType, "[]")
-			fieldType = strings.TrimPrefix(fieldType, "*")
+			// Extract referenced type from field type (handle arrays, maps and pointers)
+			fieldType := generator.ElementTypeName(field.Type)
 
 			// Check if this is a custom type (not a built-in Go type)
 			if p.isCustomTypeInDefinitions(fieldType, allTypes) {
@@ -579,6 +946,14 @@ func (p *OpenAPIParser) categorizeTypesIntoActors(model *generator.GenerationMod
 			}
 		}
 	}
+	for _, unionType := range allTypes.Unions {
+		// A union depends on every one of its variants
+		for _, variant := range unionType.Variants {
+			if p.isCustomTypeInDefinitions(variant.TypeName, allTypes) {
+				typeDependencies[unionType.Name] = append(typeDependencies[unionType.Name], variant.TypeName)
+			}
+		}
+	}
 
 	// Propagate usage from dependent types (iteratively to handle transitive dependencies)
 	changed := true
@@ -607,6 +982,7 @@ func (p *OpenAPIParser) categorizeTypesIntoActors(model *generator.GenerationMod
 			Structs: []generator.StructType{},
 			Aliases: []generator.TypeAlias{},
 			Enums:   []generator.EnumType{},
+			Unions:  []generator.UnionType{},
 		}
 	}
 
@@ -658,6 +1034,22 @@ func (p *OpenAPIParser) categorizeTypesIntoActors(model *generator.GenerationMod
 		}
 	}
 
+	// Assign union types directly to each actor that uses them
+	for _, unionType := range allTypes.Unions {
+		usedByActors := typeUsage[unionType.Name]
+
+		// Assign to each actor that uses this type
+		for actorType := range usedByActors {
+			// Find the actor and add the type to it
+			for i, actor := range model.Actors {
+				if actor.ActorType == actorType {
+					model.Actors[i].Types.Unions = append(model.Actors[i].Types.Unions, unionType)
+					break
+				}
+			}
+		}
+	}
+
 	// Sort types within each actor for consistent ordering
 	for i := range model.Actors {
 		sort.Slice(model.Actors[i].Types.Structs, func(j, k int) bool {
@@ -669,6 +1061,9 @@ func (p *OpenAPIParser) categorizeTypesIntoActors(model *generator.GenerationMod
 		sort.Slice(model.Actors[i].Types.Enums, func(j, k int) bool {
 			return model.Actors[i].Types.Enums[j].Name < model.Actors[i].Types.Enums[k].Name
 		})
+		sort.Slice(model.Actors[i].Types.Unions, func(j, k int) bool {
+			return model.Actors[i].Types.Unions[j].Name < model.Actors[i].Types.Unions[k].Name
+		})
 	}
 
 	return nil
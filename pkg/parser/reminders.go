@@ -0,0 +1,138 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/shogotsuneto/dapr-actor-gen/pkg/generator"
+)
+
+// Root vendor extensions declaring the reminders and timers of each actor:
+//
+//	x-dapr-reminders:
+//	  BankAccount:
+//	    - name: monthly-statement
+//	      callback: OnMonthlyStatement
+//	      payload:
+//	        $ref: '#/components/schemas/StatementRequest'
+const (
+	remindersExtension = "x-dapr-reminders"
+	timersExtension    = "x-dapr-timers"
+)
+
+// reminderDeclaration is a single entry of the x-dapr-reminders and x-dapr-timers extensions
+type reminderDeclaration struct {
+	Name        string              `json:"name"`
+	Callback    string              `json:"callback"`
+	Description string              `json:"description"`
+	Payload     *openapi3.SchemaRef `json:"payload"`
+}
+
+// parseActorReminders attaches the reminders and timers declared in the spec to the actors.
+// Inline payload schemas are synthesized as <Callback>Payload types.
+func (p *OpenAPIParser) parseActorReminders(model *generator.GenerationModel) error {
+	for _, extension := range []string{remindersExtension, timersExtension} {
+		declared, err := p.reminderDeclarations(extension)
+		if err != nil {
+			return err
+		}
+
+		actorTypes := make([]string, 0, len(declared))
+		for actorType := range declared {
+			actorTypes = append(actorTypes, actorType)
+		}
+		sort.Strings(actorTypes)
+
+		for _, actorType := range actorTypes {
+			actor := findActor(model, actorType)
+			if actor == nil {
+				return fmt.Errorf("%s declares callbacks for unknown actor type '%s'", extension, actorType)
+			}
+
+			declarations := declared[actorType]
+			sort.Slice(declarations, func(i, j int) bool {
+				return declarations[i].Name < declarations[j].Name
+			})
+			for _, declaration := range declarations {
+				reminder, err := p.buildReminder(actor, declaration)
+				if err != nil {
+					return fmt.Errorf("%s of %s: %v", extension, actorType, err)
+				}
+				if extension == timersExtension {
+					actor.Timers = append(actor.Timers, reminder)
+				} else {
+					actor.Reminders = append(actor.Reminders, reminder)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// reminderDeclarations decodes a reminders or timers extension, keyed by actor type
+func (p *OpenAPIParser) reminderDeclarations(extension string) (map[string][]reminderDeclaration, error) {
+	raw, ok := p.doc.Extensions[extension]
+	if !ok {
+		return nil, nil
+	}
+
+	// Extension values are kept as decoded JSON; re-decode them with their payload schemas
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s extension: %v", extension, err)
+	}
+	var declared map[string][]reminderDeclaration
+	if err := json.Unmarshal(data, &declared); err != nil {
+		return nil, fmt.Errorf("invalid %s extension: expected a map of actor types to lists of {name, callback, payload}: %v", extension, err)
+	}
+	return declared, nil
+}
+
+// buildReminder validates a reminder or timer declaration and resolves its payload type
+func (p *OpenAPIParser) buildReminder(actor *generator.ActorInterface, declaration reminderDeclaration) (generator.Reminder, error) {
+	if declaration.Name == "" {
+		return generator.Reminder{}, fmt.Errorf("every entry needs a name")
+	}
+	callback := declaration.Callback
+	if callback == "" || !unicode.IsUpper(rune(callback[0])) {
+		return generator.Reminder{}, fmt.Errorf("callback '%s' of '%s' must start with a capital letter (Go exported method requirement)", callback, declaration.Name)
+	}
+	for _, method := range actor.Methods {
+		if method.Name == callback {
+			return generator.Reminder{}, fmt.Errorf("callback '%s' of '%s' conflicts with an actor method", callback, declaration.Name)
+		}
+	}
+	for _, existing := range actor.Callbacks() {
+		if existing.Name == declaration.Name || existing.Callback == callback {
+			return generator.Reminder{}, fmt.Errorf("'%s' is declared more than once or reuses callback '%s'", declaration.Name, callback)
+		}
+	}
+
+	reminder := generator.Reminder{
+		Name:     declaration.Name,
+		Callback: callback,
+		Comment:  strings.TrimSpace(strings.SplitN(strings.TrimSpace(declaration.Description), "\n", 2)[0]),
+	}
+	if declaration.Payload != nil {
+		if err := p.resolveSchemaRefs(declaration.Payload); err != nil {
+			return generator.Reminder{}, fmt.Errorf("payload of '%s': %v", declaration.Name, err)
+		}
+		reminder.PayloadType = p.resolveInlineType(actor.ActorType, callback+"Payload", declaration.Payload)
+	}
+	return reminder, nil
+}
+
+// findActor returns the actor of the given type in the model, or nil if there is none
+func findActor(model *generator.GenerationModel, actorType string) *generator.ActorInterface {
+	for i := range model.Actors {
+		if model.Actors[i].ActorType == actorType {
+			return &model.Actors[i]
+		}
+	}
+	return nil
+}
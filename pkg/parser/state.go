@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/shogotsuneto/dapr-actor-gen/pkg/generator"
+)
+
+// actorStateExtension is the root vendor extension declaring the typed state of each actor:
+//
+//	x-dapr-actor-state:
+//	  BankAccount:
+//	    balance:
+//	      type: number
+//	    events:
+//	      type: array
+//	      items:
+//	        $ref: '#/components/schemas/AccountEvent'
+const actorStateExtension = "x-dapr-actor-state"
+
+// parseActorState attaches the state keys declared in the x-dapr-actor-state extension to the actors.
+// Inline object schemas are synthesized as <Key>State types so they are emitted with the actor's types.
+func (p *OpenAPIParser) parseActorState(model *generator.GenerationModel) error {
+	raw, ok := p.doc.Extensions[actorStateExtension]
+	if !ok {
+		return nil
+	}
+
+	// Extension values are kept as decoded JSON; re-decode them as schemas
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("invalid %s extension: %v", actorStateExtension, err)
+	}
+	var declared map[string]map[string]*openapi3.SchemaRef
+	if err := json.Unmarshal(data, &declared); err != nil {
+		return fmt.Errorf("invalid %s extension: expected a map of actor types to state key schemas: %v", actorStateExtension, err)
+	}
+
+	actorIndex := make(map[string]int)
+	for i, actor := range model.Actors {
+		actorIndex[actor.ActorType] = i
+	}
+
+	actorTypes := make([]string, 0, len(declared))
+	for actorType := range declared {
+		actorTypes = append(actorTypes, actorType)
+	}
+	sort.Strings(actorTypes)
+
+	for _, actorType := range actorTypes {
+		i, exists := actorIndex[actorType]
+		if !exists {
+			return fmt.Errorf("%s declares state for unknown actor type '%s'", actorStateExtension, actorType)
+		}
+
+		keys := make([]string, 0, len(declared[actorType]))
+		for key := range declared[actorType] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			schemaRef := declared[actorType][key]
+			if schemaRef == nil {
+				return fmt.Errorf("%s: state key '%s' of %s has no schema", actorStateExtension, key, actorType)
+			}
+			if err := p.resolveSchemaRefs(schemaRef); err != nil {
+				return fmt.Errorf("%s: state key '%s' of %s: %v", actorStateExtension, key, actorType, err)
+			}
+
+			stateKey := generator.StateKey{
+				Key:  key,
+				Type: p.resolveInlineType(actorType, capitalizeFirst(key)+"State", schemaRef),
+			}
+			if schemaRef.Ref == "" && schemaRef.Value != nil {
+				stateKey.Description = strings.TrimSpace(strings.SplitN(strings.TrimSpace(schemaRef.Value.Description), "\n", 2)[0])
+			}
+			model.Actors[i].State = append(model.Actors[i].State, stateKey)
+		}
+	}
+
+	return nil
+}
+
+// resolveSchemaRefs resolves the component $refs of a schema that was not loaded with the document
+func (p *OpenAPIParser) resolveSchemaRefs(schemaRef *openapi3.SchemaRef) error {
+	if schemaRef == nil {
+		return nil
+	}
+	if schemaRef.Ref != "" {
+		name := refTypeName(schemaRef.Ref)
+		if p.doc.Components == nil || p.doc.Components.Schemas[name] == nil {
+			return fmt.Errorf("unresolved reference '%s'", schemaRef.Ref)
+		}
+		schemaRef.Value = p.doc.Components.Schemas[name].Value
+		return nil
+	}
+
+	schema := schemaRef.Value
+	if schema == nil {
+		return nil
+	}
+	for _, property := range schema.Properties {
+		if err := p.resolveSchemaRefs(property); err != nil {
+			return err
+		}
+	}
+	for _, parts := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, part := range parts {
+			if err := p.resolveSchemaRefs(part); err != nil {
+				return err
+			}
+		}
+	}
+	if err := p.resolveSchemaRefs(schema.Items); err != nil {
+		return err
+	}
+	return p.resolveSchemaRefs(schema.AdditionalProperties.Schema)
+}
@@ -1,9 +1,11 @@
 package parser
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/shogotsuneto/dapr-actor-gen/pkg/generator"
 )
 
 // getOperationComment extracts comment from operation summary/description
@@ -19,28 +21,15 @@ func getOperationComment(op *openapi3.Operation) string {
 	return "Generated method from OpenAPI operation"
 }
 
-// extractRequestType extracts the request type name from request body
-func extractRequestType(requestBody *openapi3.RequestBody) string {
-	if requestBody.Content == nil {
-		return ""
-	}
-
-	// Look for JSON content
-	if jsonContent := requestBody.Content.Get("application/json"); jsonContent != nil {
-		if jsonContent.Schema != nil && jsonContent.Schema.Ref != "" {
-			// Extract type name from $ref
-			parts := strings.Split(jsonContent.Schema.Ref, "/")
-			if len(parts) > 0 {
-				return parts[len(parts)-1]
-			}
+// getGoType converts OpenAPI schema type to Go type, applying the parser's format mappings
+func (p *OpenAPIParser) getGoType(schema *openapi3.Schema) string {
+	if schema.Format != "" && !schema.Type.Is("array") && !schema.Type.Is("object") {
+		if mapping, ok := p.formatMappings[schema.Format]; ok {
+			p.useFormatMapping(mapping)
+			return mapping.Type
 		}
 	}
 
-	return ""
-}
-
-// getGoType converts OpenAPI schema type to Go type
-func getGoType(schema *openapi3.Schema) string {
 	switch {
 	case schema.Type.Is("string"):
 		return "string"
@@ -58,7 +47,7 @@ func getGoType(schema *openapi3.Schema) string {
 		return "bool"
 	case schema.Type.Is("array"):
 		if schema.Items != nil {
-			return "[]" + getGoType(schema.Items.Value)
+			return "[]" + p.getGoType(schema.Items.Value)
 		}
 		return "[]interface{}"
 	case schema.Type.Is("object"):
@@ -71,6 +60,62 @@ func getGoType(schema *openapi3.Schema) string {
 	}
 }
 
+// isStructSchema reports whether a schema should be generated as a struct type
+// (an object with properties, or a composition of schemas through allOf)
+func isStructSchema(schema *openapi3.Schema) bool {
+	if len(schema.AllOf) > 0 {
+		return true
+	}
+	return schema.Type.Is("object") && len(schema.Properties) > 0
+}
+
+// isInlineStructSchema reports whether a schema reference is an inline (non-$ref) struct schema
+func isInlineStructSchema(schemaRef *openapi3.SchemaRef) bool {
+	return schemaRef.Ref == "" && schemaRef.Value != nil && isStructSchema(schemaRef.Value)
+}
+
+// jsonSchemaRef returns the schema of the application/json media type, if any
+func jsonSchemaRef(content openapi3.Content) *openapi3.SchemaRef {
+	if content == nil {
+		return nil
+	}
+	if jsonContent := content.Get("application/json"); jsonContent != nil {
+		return jsonContent.Schema
+	}
+	return nil
+}
+
+// refTypeName extracts the referenced type name from a $ref
+// e.g., "#/components/schemas/CounterState" -> "CounterState"
+func refTypeName(ref string) string {
+	parts := strings.Split(ref, "/")
+	return parts[len(parts)-1]
+}
+
+// extractConstraints extracts the validation constraints declared on a property schema
+func extractConstraints(schema *openapi3.Schema) generator.Constraints {
+	constraints := generator.Constraints{
+		Minimum:          schema.Min,
+		Maximum:          schema.Max,
+		ExclusiveMinimum: schema.ExclusiveMin,
+		ExclusiveMaximum: schema.ExclusiveMax,
+		MinLength:        schema.MinLength,
+		MaxLength:        schema.MaxLength,
+		Pattern:          schema.Pattern,
+		MinItems:         schema.MinItems,
+		MaxItems:         schema.MaxItems,
+	}
+
+	// String enums are generated as enum types with their own validity check
+	if !schema.Type.Is("string") {
+		for _, enumValue := range schema.Enum {
+			constraints.Enum = append(constraints.Enum, fmt.Sprint(enumValue))
+		}
+	}
+
+	return constraints
+}
+
 // capitalizeFirst capitalizes the first letter of a string
 func capitalizeFirst(s string) string {
 	if len(s) == 0 {
@@ -1,7 +1,10 @@
 package integration
 
 import (
+	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -159,6 +162,100 @@ func TestMultiActorTypeDuplication(t *testing.T) {
 	}
 }
 
+func TestSharedTypesPackage(t *testing.T) {
+	// Load the shared types spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/shared-types.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	gen := &generator.Generator{}
+	outputDir := "test-output/shared-types"
+	options := generator.GenerationOptions{
+		SharedTypes:        true,
+		GenerateValidation: true,
+		GenerateImpl:       true,
+		ModulePath:         "github.com/example/shop/generated",
+	}
+	err = gen.GenerateActorPackages(model, outputDir, options)
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	// Types used by both actors are declared once in the shared package
+	sharedContent, err := os.ReadFile(filepath.Join(outputDir, "types", "types.go"))
+	if err != nil {
+		t.Fatalf("Failed to read shared types.go: %v", err)
+	}
+	for _, expected := range []string{"package types", "type Money struct", "type Currency string"} {
+		if !strings.Contains(string(sharedContent), expected) {
+			t.Errorf("Expected '%s' in shared types.go. Got:\n%s", expected, sharedContent)
+		}
+	}
+	for _, unexpected := range []string{"type AddItemRequest struct", "type Receipt struct"} {
+		if strings.Contains(string(sharedContent), unexpected) {
+			t.Errorf("Expected actor-specific '%s' to stay out of shared types.go", unexpected)
+		}
+	}
+
+	// Actor packages import the shared package and re-export its types as aliases
+	cartContent, err := os.ReadFile(filepath.Join(outputDir, "cart", "types.go"))
+	if err != nil {
+		t.Fatalf("Failed to read cart types.go: %v", err)
+	}
+	expectedCart := []string{
+		`"github.com/example/shop/generated/types"`,
+		"Money    = types.Money",
+		"CurrencyEUR = types.CurrencyEUR",
+		"type AddItemRequest struct",
+		`errs.addNested(path+"price"+".", v.Price.Validate())`,
+	}
+	for _, expected := range expectedCart {
+		if !strings.Contains(string(cartContent), expected) {
+			t.Errorf("Expected '%s' in cart types.go. Got:\n%s", expected, cartContent)
+		}
+	}
+	if strings.Contains(string(cartContent), "type Money struct") {
+		t.Errorf("Expected Money not to be redeclared in cart types.go. Got:\n%s", cartContent)
+	}
+
+	// The model keeps the per-actor type lists
+	for _, actor := range model.Actors {
+		found := false
+		for _, structType := range actor.Types.Structs {
+			if structType.Name == "Money" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected Money to remain in the model types of %s", actor.ActorType)
+		}
+	}
+
+	// Variants of a union that stays local to an actor stay local as well, since the union
+	// declares methods on them
+	if !strings.Contains(string(cartContent), "type Discount struct") {
+		t.Errorf("Expected the Adjustment variant Discount to be declared in cart types.go. Got:\n%s", cartContent)
+	}
+	if strings.Contains(string(sharedContent), "type Discount struct") {
+		t.Errorf("Expected the union variant Discount to stay out of shared types.go")
+	}
+
+	buildGeneratedPackages(t, outputDir, options.ModulePath)
+}
+
 func TestTypeAliasGeneration(t *testing.T) {
 	// Load the type alias test OpenAPI spec
 	loader := openapi3.NewLoader()
@@ -248,6 +345,19 @@ func TestGeneratorWithTestSpecs(t *testing.T) {
 		{"Basic Actor", "testdata/basic-actor.yaml"},
 		{"Multi Actor", "testdata/multi-actor.yaml"},
 		{"Type Alias", "testdata/type-alias.yaml"},
+		{"AllOf Composition", "testdata/all-of.yaml"},
+		{"Discriminator", "testdata/discriminator.yaml"},
+		{"Inline Objects", "testdata/inline-objects.yaml"},
+		{"Optional Fields", "testdata/optional-fields.yaml"},
+		{"Formats", "testdata/formats.yaml"},
+		{"Validation", "testdata/validation.yaml"},
+		{"Shared Types", "testdata/shared-types.yaml"},
+		{"Actor State", "testdata/actor-state.yaml"},
+		{"Reminders", "testdata/reminders.yaml"},
+		{"Error Responses", "testdata/error-responses.yaml"},
+		{"Success Responses", "testdata/success-responses.yaml"},
+		{"Request Bodies", "testdata/request-bodies.yaml"},
+		{"Actor Config", "testdata/actor-config.yaml"},
 	}
 
 	for _, tt := range tests {
@@ -330,6 +440,216 @@ func TestGeneratorWithPartialImplementation(t *testing.T) {
 	t.Logf("Successfully generated actor packages with partial implementation")
 }
 
+func TestGeneratorWithMergeImpl(t *testing.T) {
+	// Load the basic actor spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/basic-actor.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	// Simulate an older spec by dropping the last method
+	actor := &model.Actors[0]
+	allMethods := actor.Methods
+	newMethod := allMethods[len(allMethods)-1]
+	actor.Methods = allMethods[:len(allMethods)-1]
+
+	gen := &generator.Generator{}
+	outputDir := "test-output/merge-impl"
+	err = gen.GenerateActorPackages(model, outputDir, generator.GenerationOptions{GenerateImpl: true})
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages with impl: %v", err)
+	}
+
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	// Add business logic and a helper to the generated implementation
+	implFile := filepath.Join(outputDir, strings.ToLower(actor.ActorType), "impl.go")
+	content, err := os.ReadFile(implFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated impl.go: %v", err)
+	}
+	firstMethod := actor.Methods[0].Name
+	stub := fmt.Sprintf(`errors.New("%s method is not implemented")`, firstMethod)
+	if !strings.Contains(string(content), stub) {
+		t.Fatalf("Expected stub for %s in impl.go. Got:\n%s", firstMethod, content)
+	}
+	edited := strings.Replace(string(content), stub, `errors.New("custom business logic")`, 1)
+	edited += "\nfunc (a *" + actor.ActorType + ") helper() int { return 42 }\n"
+	err = os.WriteFile(implFile, []byte(edited), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write edited impl.go: %v", err)
+	}
+
+	// Regenerate in merge mode with the new method added back to the spec
+	actor.Methods = allMethods
+	err = gen.GenerateActorPackages(model, outputDir, generator.GenerationOptions{MergeImpl: true})
+	if err != nil {
+		t.Fatalf("Failed to merge impl.go: %v", err)
+	}
+
+	merged, err := os.ReadFile(implFile)
+	if err != nil {
+		t.Fatalf("Failed to read merged impl.go: %v", err)
+	}
+	expected := []string{
+		`errors.New("custom business logic")`,
+		"helper() int { return 42 }",
+		fmt.Sprintf(`errors.New("%s method is not implemented")`, newMethod.Name),
+	}
+	for _, e := range expected {
+		if !strings.Contains(string(merged), e) {
+			t.Errorf("Expected '%s' in merged impl.go. Got:\n%s", e, merged)
+		}
+	}
+	if strings.Contains(string(merged), stub) {
+		t.Errorf("Expected existing method body of %s to be preserved. Got:\n%s", firstMethod, merged)
+	}
+	if count := strings.Count(string(merged), "type "+actor.ActorType+" struct"); count != 1 {
+		t.Errorf("Expected actor struct to be declared once, found %d. Got:\n%s", count, merged)
+	}
+}
+
+func TestCheckActorPackages(t *testing.T) {
+	// Load the basic actor spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/basic-actor.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	gen := &generator.Generator{}
+	outputDir := "test-output/check"
+	options := generator.GenerationOptions{}
+	err = gen.GenerateActorPackages(model, outputDir, options)
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	// Freshly generated code is up to date
+	var diff strings.Builder
+	outdated, err := gen.CheckActorPackages(model, outputDir, options, &diff)
+	if err != nil {
+		t.Fatalf("Failed to check actor packages: %v", err)
+	}
+	if len(outdated) != 0 || diff.Len() != 0 {
+		t.Fatalf("Expected no outdated files, got %v:\n%s", outdated, diff.String())
+	}
+
+	// Edit one generated file and remove another
+	packageDir := filepath.Join(outputDir, strings.ToLower(model.Actors[0].ActorType))
+	apiFile := filepath.Join(packageDir, "api.go")
+	content, err := os.ReadFile(apiFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated api.go: %v", err)
+	}
+	edited := strings.Replace(string(content), "import (", "// hand edit\nimport (", 1)
+	if err := os.WriteFile(apiFile, []byte(edited), 0644); err != nil {
+		t.Fatalf("Failed to write api.go: %v", err)
+	}
+	clientFile := filepath.Join(packageDir, "client.go")
+	if err := os.Remove(clientFile); err != nil {
+		t.Fatalf("Failed to remove client.go: %v", err)
+	}
+
+	diff.Reset()
+	outdated, err = gen.CheckActorPackages(model, outputDir, options, &diff)
+	if err != nil {
+		t.Fatalf("Failed to check actor packages: %v", err)
+	}
+	if strings.Join(outdated, ",") != apiFile+","+clientFile {
+		t.Errorf("Expected outdated files [%s %s], got %v", apiFile, clientFile, outdated)
+	}
+	expectedDiff := []string{
+		"--- " + apiFile + "\n+++ " + apiFile + " (generated)\n",
+		"-// hand edit\n",
+		"--- " + clientFile + "\n",
+	}
+	for _, expected := range expectedDiff {
+		if !strings.Contains(diff.String(), expected) {
+			t.Errorf("Expected '%s' in diff. Got:\n%s", expected, diff.String())
+		}
+	}
+
+	// Check mode does not touch the disk
+	if _, err := os.Stat(clientFile); !os.IsNotExist(err) {
+		t.Errorf("Expected check mode not to recreate %s", clientFile)
+	}
+	content, err = os.ReadFile(apiFile)
+	if err != nil {
+		t.Fatalf("Failed to read api.go: %v", err)
+	}
+	if string(content) != edited {
+		t.Errorf("Expected check mode not to modify %s", apiFile)
+	}
+
+	// Generated files of an actor removed from the spec are extraneous; hand-written files are not
+	removedFile := filepath.Join(outputDir, "removed", "api.go")
+	if err := os.MkdirAll(filepath.Dir(removedFile), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(removedFile, content, 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", removedFile, err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "removed", "helper.go"), []byte("package removed\n"), 0644); err != nil {
+		t.Fatalf("Failed to write helper.go: %v", err)
+	}
+	diff.Reset()
+	outdated, err = gen.CheckActorPackages(model, outputDir, options, &diff)
+	if err != nil {
+		t.Fatalf("Failed to check actor packages: %v", err)
+	}
+	if len(outdated) != 3 || outdated[2] != removedFile {
+		t.Errorf("Expected %s to be reported as extraneous, got %v", removedFile, outdated)
+	}
+	if !strings.Contains(diff.String(), "--- "+removedFile+"\n+++ /dev/null\n") {
+		t.Errorf("Expected a deletion diff for %s. Got:\n%s", removedFile, diff.String())
+	}
+
+	// Merging the implementation prints nothing in check mode
+	mergeOptions := generator.GenerationOptions{MergeImpl: true}
+	if err := gen.GenerateActorPackages(model, outputDir, mergeOptions); err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+	stdout := os.Stdout
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	os.Stdout = writer
+	model.Actors[0].Methods = model.Actors[0].Methods[1:]
+	_, err = gen.CheckActorPackages(model, outputDir, mergeOptions, io.Discard)
+	os.Stdout = stdout
+	writer.Close()
+	printed, _ := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to check actor packages: %v", err)
+	}
+	if len(printed) != 0 {
+		t.Errorf("Expected check mode to print nothing, got:\n%s", printed)
+	}
+}
+
 func TestGeneratorWithExampleApplication(t *testing.T) {
 	// Load the multi-actor spec
 	loader := openapi3.NewLoader()
@@ -372,61 +692,1882 @@ func TestGeneratorWithExampleApplication(t *testing.T) {
 		t.Errorf("Expected go.mod file not found: %s", goModFile)
 	}
 
+	// The service is stopped gracefully with a configurable timeout instead of exiting on the signal
+	content, err := os.ReadFile(mainFile)
+	if err != nil {
+		t.Fatalf("Failed to read main.go: %v", err)
+	}
+	for _, e := range []string{
+		`flag.String("addr", envOrDefault("APP_ADDR", ":"+envOrDefault("APP_PORT", "8080"))`,
+		`flag.Duration("shutdown-timeout", defaultTimeout`,
+		"os.Exit(shutdown(s, serveErr, *shutdownTimeout))",
+		"stopped <- s.GracefulStop()",
+	} {
+		if !strings.Contains(string(content), e) {
+			t.Errorf("Expected '%s' in main.go. Got:\n%s", e, content)
+		}
+	}
+
 	t.Logf("Successfully generated actor packages with example application")
 }
 
-func TestEnumGeneration(t *testing.T) {
-	// Load the type-alias OpenAPI spec (which includes enums)
+func TestExampleApplicationTransports(t *testing.T) {
 	loader := openapi3.NewLoader()
-	doc, err := loader.LoadFromFile("testdata/type-alias.yaml")
+	doc, err := loader.LoadFromFile("testdata/multi-actor.yaml")
 	if err != nil {
-		t.Fatalf("Failed to load type-alias OpenAPI spec: %v", err)
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+	model, err := parser.NewOpenAPIParser(doc).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	gen := &generator.Generator{}
+	render := func(transport string) (string, error) {
+		files, err := gen.RenderActorPackages(model, "out", generator.GenerationOptions{GenerateExample: true, Transport: transport})
+		if err != nil {
+			return "", err
+		}
+		for _, file := range files {
+			if file.Path == filepath.Join("out", "main.go") {
+				return string(file.Content), nil
+			}
+		}
+		return "", fmt.Errorf("main.go was not generated")
+	}
+
+	// The chi router is the default
+	for _, transport := range []string{"", generator.TransportHTTPChi} {
+		content, err := render(transport)
+		if err != nil {
+			t.Fatalf("Failed to render example for transport '%s': %v", transport, err)
+		}
+		if !strings.Contains(content, "daprd.NewServiceWithMux(*addr, r)") {
+			t.Errorf("Expected a chi router for transport '%s'. Got:\n%s", transport, content)
+		}
+	}
+
+	content, err := render(generator.TransportHTTP)
+	if err != nil {
+		t.Fatalf("Failed to render example for transport http: %v", err)
+	}
+	if !strings.Contains(content, "daprd.NewService(*addr)") || strings.Contains(content, "chi") {
+		t.Errorf("Expected the default router of the Dapr SDK without chi. Got:\n%s", content)
+	}
+
+	// Actors cannot be hosted by the gRPC service of the Dapr SDK
+	if _, err := render(generator.TransportGRPC); err == nil || !strings.Contains(err.Error(), "does not support hosting actors") {
+		t.Errorf("Expected an unsupported transport error for grpc, got: %v", err)
+	}
+}
+
+func TestExampleApplicationInModule(t *testing.T) {
+	// Generate into a directory of an existing module
+	moduleDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(moduleDir, "go.mod"), []byte("module github.com/acme/service\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	outputDir := filepath.Join(moduleDir, "internal", "actors")
+	modulePath, err := generator.DetectModulePath(outputDir)
+	if err != nil {
+		t.Fatalf("Failed to detect module path: %v", err)
+	}
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/multi-actor.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+	model, err := parser.NewOpenAPIParser(doc).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	gen := &generator.Generator{}
+	options := generator.GenerationOptions{GenerateExample: true, ModulePath: modulePath, InModule: true}
+	err = gen.GenerateActorPackages(model, outputDir, options)
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+
+	// The example imports the actor packages through the enclosing module and gets no go.mod of its own
+	content, err := os.ReadFile(filepath.Join(outputDir, "main.go"))
+	if err != nil {
+		t.Fatalf("Failed to read main.go: %v", err)
+	}
+	if !strings.Contains(string(content), `"github.com/acme/service/internal/actors/counter"`) {
+		t.Errorf("Expected main.go to import the counter package through the module. Got:\n%s", content)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "go.mod")); !os.IsNotExist(err) {
+		t.Errorf("Expected no go.mod in the output directory inside a module")
+	}
+}
+
+func TestPackageNamingAndTemplateOverrides(t *testing.T) {
+	// Load the multi-actor spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/multi-actor.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
 	}
 
-	// Parse the spec to intermediate model
 	p := parser.NewOpenAPIParser(doc)
 	model, err := p.Parse()
 	if err != nil {
 		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
 	}
 
-	// Find the User actor (which should have UserStatus enum)
-	var userActor *generator.ActorInterface
-	for i := range model.Actors {
-		if model.Actors[i].ActorType == "User" {
-			userActor = &model.Actors[i]
-			break
-		}
-	}
-	if userActor == nil {
-		t.Fatal("User actor not found")
+	// Override the client template with a file of the same name
+	templatesDir := t.TempDir()
+	override := "package {{.PackageName}}\n\n// Custom client for {{.Actor.ActorType}}\n"
+	if err := os.WriteFile(filepath.Join(templatesDir, "client.tmpl"), []byte(override), 0644); err != nil {
+		t.Fatalf("Failed to write template override: %v", err)
 	}
 
-	// Verify that enum types are generated for User
-	if len(userActor.Types.Enums) == 0 {
-		t.Error("Expected enum types to be generated for User actor, but found none")
+	gen := &generator.Generator{}
+	outputDir := "test-output/package-naming"
+	options := generator.GenerationOptions{
+		GenerateExample: true,
+		ModulePath:      "example.com/actors",
+		PackageSuffix:   "actor",
+		PackageNames:    map[string]string{"Calculator": "calc"},
+		TemplatesDir:    templatesDir,
+	}
+	err = gen.GenerateActorPackages(model, outputDir, options)
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
 	}
 
-	// Look for UserStatus enum
-	var userStatusEnum *generator.EnumType
-	for i := range userActor.Types.Enums {
-		if userActor.Types.Enums[i].Name == "UserStatus" {
-			userStatusEnum = &userActor.Types.Enums[i]
-			break
-		}
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	expectedContent := map[string][]string{
+		"counteractor/api.go":    {"package counteractor"},
+		"counteractor/client.go": {"// Custom client for Counter"},
+		"calc/api.go":            {"package calc"},
+		"main.go": {
+			`"example.com/actors/calc"`,
+			`"example.com/actors/counteractor"`,
+			"calc.NewActorFactory()",
+		},
 	}
-	if userStatusEnum == nil {
-		t.Error("Expected UserStatus enum not found in User actor")
-	} else {
-		// Verify enum has correct values
-		expectedValues := []string{"active", "inactive", "suspended", "pending"}
-		if len(userStatusEnum.Values) != len(expectedValues) {
-			t.Errorf("Expected UserStatus to have %d values, got %d", len(expectedValues), len(userStatusEnum.Values))
+	for fileName, expected := range expectedContent {
+		content, err := os.ReadFile(filepath.Join(outputDir, fileName))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", fileName, err)
 		}
-		for i, expected := range expectedValues {
-			if i >= len(userStatusEnum.Values) || userStatusEnum.Values[i] != expected {
-				t.Errorf("Expected UserStatus value[%d] to be '%s', got '%s'", i, expected, userStatusEnum.Values[i])
+		for _, e := range expected {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("Expected '%s' in %s. Got:\n%s", e, fileName, content)
 			}
 		}
 	}
+
+	// Two actors cannot share a package
+	options.PackageNames = map[string]string{"Calculator": "counteractor"}
+	if _, err := gen.RenderActorPackages(model, outputDir, options); err == nil || !strings.Contains(err.Error(), "would both be generated into package counteractor") {
+		t.Errorf("Expected a package conflict error, got: %v", err)
+	}
+}
+
+func TestUserTemplates(t *testing.T) {
+	// Load the multi-actor spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/multi-actor.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	// Additional templates are rendered per actor (actor/) and once per model (model/)
+	templatesDir := t.TempDir()
+	templates := map[string]string{
+		"actor/methods.go.tmpl": "package {{.PackageName}}\n\n// MethodNames lists the methods of the {{.ActorType}} actor\n" +
+			"var MethodNames = []string{ {{- range .ActorInterface.Methods}}\"{{.Name | ToLower}}\", {{end -}} }\n",
+		"model/ACTORS.md.tmpl": "{{range .Actors}}- {{.ActorType}}\n{{end}}",
+	}
+	for name, content := range templates {
+		path := filepath.Join(templatesDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create template directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write template: %v", err)
+		}
+	}
+
+	gen := &generator.Generator{}
+	outputDir := "test-output/user-templates"
+	options := generator.GenerationOptions{TemplatesDir: templatesDir}
+	err = gen.GenerateActorPackages(model, outputDir, options)
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	expectedContent := map[string]string{
+		"counter/methods.go":    `var MethodNames = []string{"getcount", "increment", "reset"}`,
+		"calculator/methods.go": "package calculator",
+		"ACTORS.md":             "- Calculator\n- Counter\n",
+	}
+	for fileName, expected := range expectedContent {
+		content, err := os.ReadFile(filepath.Join(outputDir, fileName))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", fileName, err)
+		}
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("Expected '%s' in %s. Got:\n%s", expected, fileName, content)
+		}
+	}
+
+	// Templates at the top level must override a built-in template
+	if err := os.WriteFile(filepath.Join(templatesDir, "clinet.tmpl"), []byte(""), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	if _, err := gen.RenderActorPackages(model, outputDir, options); err == nil || !strings.Contains(err.Error(), "does not override a built-in template") {
+		t.Errorf("Expected an unknown template error, got: %v", err)
+	}
+}
+
+func TestEnumGeneration(t *testing.T) {
+	// Load the type-alias OpenAPI spec (which includes enums)
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/type-alias.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load type-alias OpenAPI spec: %v", err)
+	}
+
+	// Parse the spec to intermediate model
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	// Find the User actor (which should have UserStatus enum)
+	var userActor *generator.ActorInterface
+	for i := range model.Actors {
+		if model.Actors[i].ActorType == "User" {
+			userActor = &model.Actors[i]
+			break
+		}
+	}
+	if userActor == nil {
+		t.Fatal("User actor not found")
+	}
+
+	// Verify that enum types are generated for User
+	if len(userActor.Types.Enums) == 0 {
+		t.Error("Expected enum types to be generated for User actor, but found none")
+	}
+
+	// Look for UserStatus enum
+	var userStatusEnum *generator.EnumType
+	for i := range userActor.Types.Enums {
+		if userActor.Types.Enums[i].Name == "UserStatus" {
+			userStatusEnum = &userActor.Types.Enums[i]
+			break
+		}
+	}
+	if userStatusEnum == nil {
+		t.Error("Expected UserStatus enum not found in User actor")
+	} else {
+		// Verify enum has correct values
+		expectedValues := []string{"active", "inactive", "suspended", "pending"}
+		if len(userStatusEnum.Values) != len(expectedValues) {
+			t.Errorf("Expected UserStatus to have %d values, got %d", len(expectedValues), len(userStatusEnum.Values))
+		}
+		for i, expected := range expectedValues {
+			if i >= len(userStatusEnum.Values) || userStatusEnum.Values[i] != expected {
+				t.Errorf("Expected UserStatus value[%d] to be '%s', got '%s'", i, expected, userStatusEnum.Values[i])
+			}
+		}
+	}
+}
+
+func TestGeneratorWithClient(t *testing.T) {
+	// Load the multi-actor spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/multi-actor.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	// Generate actor packages (client proxies are always generated)
+	gen := &generator.Generator{}
+	outputDir := "test-output/client"
+	err = gen.GenerateActorPackages(model, outputDir, generator.GenerationOptions{})
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	// Verify client.go files exist and mirror the actor methods
+	for _, actor := range model.Actors {
+		packageName := strings.ToLower(actor.ActorType)
+		clientFile := filepath.Join(outputDir, packageName, "client.go")
+		content, err := os.ReadFile(clientFile)
+		if err != nil {
+			t.Fatalf("Expected client.go file not found: %s", clientFile)
+		}
+
+		source := string(content)
+		constructor := "func New" + actor.ActorType + "Client(client dapr.Client, actorID string) *" + actor.ActorType + "Client"
+		if !strings.Contains(source, constructor) {
+			t.Errorf("Expected constructor '%s' in %s", constructor, clientFile)
+		}
+		for _, method := range actor.Methods {
+			signature := "func (c *" + actor.ActorType + "Client) " + method.Name + "(ctx context.Context"
+			if !strings.Contains(source, signature) {
+				t.Errorf("Expected client method '%s' in %s", signature, clientFile)
+			}
+		}
+	}
+}
+
+func TestGeneratorWithMock(t *testing.T) {
+	// Load the multi-actor spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/multi-actor.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	gen := &generator.Generator{}
+	outputDir := "test-output/mock"
+	err = gen.GenerateActorPackages(model, outputDir, generator.GenerationOptions{GenerateMock: true})
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	for _, actor := range model.Actors {
+		packageName := strings.ToLower(actor.ActorType)
+		mockFile := filepath.Join(outputDir, packageName, "mock.go")
+		content, err := os.ReadFile(mockFile)
+		if err != nil {
+			t.Fatalf("Expected mock.go file not found: %s", mockFile)
+		}
+
+		source := string(content)
+		mockType := "Mock" + actor.ActorType
+		expected := []string{
+			"type " + mockType + " struct",
+			"func New" + mockType + "() *" + mockType,
+			"= (*" + mockType + ")(nil)",
+			actor.InterfaceName,
+			actor.ActorType + "ClientAPI",
+			"func (m *" + mockType + ") CallsTo(method string) []" + mockType + "Call",
+		}
+		for _, method := range actor.Methods {
+			expected = append(expected,
+				method.Name+"Func func(ctx context.Context",
+				"func (m *"+mockType+") "+method.Name+"(ctx context.Context",
+				`m.record("`+method.Name+`", `,
+			)
+		}
+		for _, e := range expected {
+			if !strings.Contains(source, e) {
+				t.Errorf("Expected '%s' in %s. Got:\n%s", e, mockFile, source)
+			}
+		}
+
+		// The client exposes an interface the mock can stand in for
+		clientContent, err := os.ReadFile(filepath.Join(outputDir, packageName, "client.go"))
+		if err != nil {
+			t.Fatalf("Failed to read client.go: %v", err)
+		}
+		clientInterface := "type " + actor.ActorType + "ClientAPI interface"
+		if !strings.Contains(string(clientContent), clientInterface) {
+			t.Errorf("Expected '%s' in client.go", clientInterface)
+		}
+	}
+}
+
+func TestGeneratorWithActorState(t *testing.T) {
+	// Load the actor state spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/actor-state.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	// State keys are attached to the actor in a stable order
+	wallet := model.Actors[0]
+	expectedState := map[string]string{
+		"balance":      "float64",
+		"owner":        "OwnerState",
+		"transactions": "[]Transaction",
+	}
+	if len(wallet.State) != len(expectedState) {
+		t.Fatalf("Expected %d state keys, got %+v", len(expectedState), wallet.State)
+	}
+	for i, key := range []string{"balance", "owner", "transactions"} {
+		if wallet.State[i].Key != key || wallet.State[i].Type != expectedState[key] {
+			t.Errorf("Expected state key %s of type %s, got %+v", key, expectedState[key], wallet.State[i])
+		}
+	}
+
+	// Types only used by the state are emitted with the actor's types
+	for _, typeName := range []string{"Transaction", "OwnerState"} {
+		found := false
+		for _, structType := range wallet.Types.Structs {
+			if structType.Name == typeName {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected state type %s in the Wallet types", typeName)
+		}
+	}
+	foundEnum := false
+	for _, enumType := range wallet.Types.Enums {
+		if enumType.Name == "TransactionKind" {
+			foundEnum = true
+		}
+	}
+	if !foundEnum {
+		t.Error("Expected enum TransactionKind referenced by a state type in the Wallet types")
+	}
+
+	gen := &generator.Generator{}
+	outputDir := "test-output/actor-state"
+	err = gen.GenerateActorPackages(model, outputDir, generator.GenerationOptions{})
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	stateFile := filepath.Join(outputDir, "wallet", "state.go")
+	content, err := os.ReadFile(stateFile)
+	if err != nil {
+		t.Fatalf("Expected state.go file not found: %s", stateFile)
+	}
+	source := string(content)
+	expected := []string{
+		"type State struct",
+		"func NewState(sm actor.StateManagerContext) *State",
+		`StateKeyBalance      = "balance"`,
+		"func (s *State) GetBalance(ctx context.Context) (float64, error)",
+		"func (s *State) SetBalance(ctx context.Context, value float64) error",
+		"func (s *State) DeleteBalance(ctx context.Context) error",
+		"func (s *State) HasBalance(ctx context.Context) (bool, error)",
+		"func (s *State) GetTransactions(ctx context.Context) ([]Transaction, error)",
+		"func (s *State) SetOwner(ctx context.Context, value OwnerState) error",
+		"// Current balance of the wallet",
+	}
+	for _, e := range expected {
+		if !strings.Contains(source, e) {
+			t.Errorf("Expected '%s' in state.go. Got:\n%s", e, source)
+		}
+	}
+
+	typesContent, err := os.ReadFile(filepath.Join(outputDir, "wallet", "types.go"))
+	if err != nil {
+		t.Fatalf("Failed to read types.go: %v", err)
+	}
+	for _, e := range []string{"type OwnerState struct", "type Transaction struct", "type TransactionKind string"} {
+		if !strings.Contains(string(typesContent), e) {
+			t.Errorf("Expected '%s' in types.go", e)
+		}
+	}
+
+	// Actors without declared state get no state.go
+	doc, err = loader.LoadFromFile("testdata/basic-actor.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+	model, err = parser.NewOpenAPIParser(doc).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+	plainDir := "test-output/actor-state-none"
+	if err := gen.GenerateActorPackages(model, plainDir, generator.GenerationOptions{}); err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+	defer os.RemoveAll(plainDir)
+	matches, _ := filepath.Glob(filepath.Join(plainDir, "*", "state.go"))
+	if len(matches) > 0 {
+		t.Errorf("Expected no state.go without declared state, got %v", matches)
+	}
+}
+
+func TestActorStateUnknownActor(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/actor-state.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+	doc.Extensions["x-dapr-actor-state"] = map[string]interface{}{
+		"Missing": map[string]interface{}{"value": map[string]interface{}{"type": "string"}},
+	}
+
+	_, err = parser.NewOpenAPIParser(doc).Parse()
+	if err == nil || !strings.Contains(err.Error(), "unknown actor type 'Missing'") {
+		t.Errorf("Expected an unknown actor type error, got: %v", err)
+	}
+}
+
+func TestGeneratorWithActorConfig(t *testing.T) {
+	// Load the actor config spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/actor-config.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	// Settings are read from the root and from info; actors without settings have none
+	configs := make(map[string]*generator.RuntimeConfig)
+	for _, actor := range model.Actors {
+		configs[actor.ActorType] = actor.Config
+	}
+	thermostat := configs["Thermostat"]
+	if thermostat == nil || thermostat.IdleTimeout != "1h" || thermostat.RemindersStoragePartitions != 7 ||
+		!thermostat.ReentrancyEnabled || thermostat.ReentrancyMaxStackDepth != 16 ||
+		thermostat.DrainRebalancedActors == nil || *thermostat.DrainRebalancedActors {
+		t.Errorf("Unexpected Thermostat config: %+v", thermostat)
+	}
+	if sensor := configs["Sensor"]; sensor == nil || sensor.IdleTimeout != "10m" || sensor.Serializer != "json" {
+		t.Errorf("Unexpected Sensor config: %+v", sensor)
+	}
+	if configs["Display"] != nil {
+		t.Errorf("Expected no config for Display, got %+v", configs["Display"])
+	}
+
+	gen := &generator.Generator{}
+	outputDir := "test-output/actor-config"
+	options := generator.GenerationOptions{GenerateExample: true, GenerateImpl: true}
+	err = gen.GenerateActorPackages(model, outputDir, options)
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	expectedContent := map[string][]string{
+		"thermostat/config.go": {
+			`IdleTimeout:                "1h",`,
+			"DrainRebalancedActors:      false,",
+			"ReentrancyMaxStackDepth:    16,",
+		},
+		"sensor/config.go": {
+			`Serializer:  "json",`,
+			"options = append(options, config.WithSerializerName(cfg.Serializer))",
+		},
+		"main.go": {
+			"s.RegisterActorImplFactoryContext(thermostat.NewActorFactory(), thermostat.Options()...)",
+			"s.RegisterActorImplFactoryContext(display.NewActorFactory())",
+		},
+	}
+	for fileName, expected := range expectedContent {
+		content, err := os.ReadFile(filepath.Join(outputDir, fileName))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", fileName, err)
+		}
+		for _, e := range expected {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("Expected '%s' in %s. Got:\n%s", e, fileName, content)
+			}
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "display", "config.go")); !os.IsNotExist(err) {
+		t.Errorf("Expected no config.go for an actor without runtime settings")
+	}
+
+	// Options only uses the options the Dapr Go SDK provides
+	buildGeneratedPackages(t, outputDir, "example-dapr-actors")
+}
+
+func TestInvalidActorConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		expected string
+	}{
+		{"unknown setting", map[string]interface{}{"Thermostat": map[string]interface{}{"idleTimout": "1h"}}, "unknown field"},
+		{"invalid duration", map[string]interface{}{"Thermostat": map[string]interface{}{"scanInterval": "often"}}, "scanInterval 'often' must be a positive duration"},
+		{"unsupported serializer", map[string]interface{}{"Thermostat": map[string]interface{}{"serializer": "yaml"}}, "x-dapr-actor-config of Thermostat: serializer 'yaml' is not supported"},
+		{"unknown actor", map[string]interface{}{"Heater": map[string]interface{}{"idleTimeout": "1h"}}, "unknown actor type 'Heater'"},
+		{"declared twice", map[string]interface{}{"Sensor": map[string]interface{}{"idleTimeout": "1h"}}, "declared both at the root and in info"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loader := openapi3.NewLoader()
+			doc, err := loader.LoadFromFile("testdata/actor-config.yaml")
+			if err != nil {
+				t.Fatalf("Failed to load OpenAPI spec: %v", err)
+			}
+			doc.Extensions["x-dapr-actor-config"] = test.config
+
+			_, err = parser.NewOpenAPIParser(doc).Parse()
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("Expected an error containing '%s', got: %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestSwaggerConversion(t *testing.T) {
+	// Swagger 2.0 specs are converted to OpenAPI 3 while loading
+	doc, warnings, err := parser.LoadSpec("testdata/swagger2.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load Swagger 2.0 spec: %v", err)
+	}
+
+	expectedWarnings := []string{
+		"GET /Inventory/{actorId}/method/GetItems: collectionFormat csv of parameter tags is not carried over",
+		"POST /Inventory/{actorId}/method/Rename: formData parameters name are converted to the properties of an object request body",
+	}
+	if strings.Join(warnings, "\n") != strings.Join(expectedWarnings, "\n") {
+		t.Errorf("Expected warnings %q, got %q", expectedWarnings, warnings)
+	}
+
+	model, err := parser.NewOpenAPIParser(doc).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse converted spec: %v", err)
+	}
+	if len(model.Actors) != 1 || model.Actors[0].ActorType != "Inventory" {
+		t.Fatalf("Expected the Inventory actor, got %+v", model.Actors)
+	}
+	// Extensions of the Swagger 2.0 document are kept
+	if config := model.Actors[0].Config; config == nil || config.IdleTimeout != "30m" {
+		t.Errorf("Expected the runtime config declared in info, got %+v", config)
+	}
+
+	gen := &generator.Generator{}
+	outputDir := "test-output/swagger2"
+	err = gen.GenerateActorPackages(model, outputDir, generator.GenerationOptions{OptionalPointers: true})
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	expectedContent := map[string][]string{
+		"inventory/api.go": {
+			"AddItem(ctx context.Context, request AddItemRequest) (*InventoryState, error)",
+			"GetItems(ctx context.Context) (*InventoryState, error)",
+			"Rename(ctx context.Context, request RenameRequest) error",
+		},
+		"inventory/types.go": {
+			"Items []Item `json:\"items\"`",
+			"Note *string `json:\"note,omitempty\"`",
+			"Name string `json:\"name\"`",
+		},
+	}
+	for fileName, expected := range expectedContent {
+		content, err := os.ReadFile(filepath.Join(outputDir, fileName))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", fileName, err)
+		}
+		for _, e := range expected {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("Expected '%s' in %s. Got:\n%s", e, fileName, content)
+			}
+		}
+	}
+}
+
+func TestSwaggerUnquotedVersion(t *testing.T) {
+	// YAML decodes an unquoted 2.0 as the number 2
+	doc, _, err := parser.LoadSpec("testdata/swagger2-unquoted.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load Swagger 2.0 spec with an unquoted version: %v", err)
+	}
+
+	model, err := parser.NewOpenAPIParser(doc).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse converted spec: %v", err)
+	}
+	if len(model.Actors) != 1 || model.Actors[0].ActorType != "Tally" || len(model.Actors[0].Methods) != 1 {
+		t.Fatalf("Expected the Tally actor with one method, got %+v", model.Actors)
+	}
+	if method := model.Actors[0].Methods[0]; method.RequestType != "int" || method.ReturnType != "int" {
+		t.Errorf("Expected Increment to take and return an int, got %s and %s", method.RequestType, method.ReturnType)
+	}
+}
+
+func TestOpenAPI31Normalization(t *testing.T) {
+	// OpenAPI 3.1 specs are normalized to OpenAPI 3.0 while loading
+	doc, warnings, err := parser.LoadSpec("testdata/openapi31.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI 3.1 spec: %v", err)
+	}
+
+	// Unsupported keywords are reported
+	expectedWarnings := []string{
+		"#/components/schemas/OrderState/properties/position: prefixItems is not supported; the tuple is generated as an array of interface{}",
+		"#/components/schemas/OrderState/properties/reference: type [string, integer] is not supported; the value is generated as interface{}",
+		"#/components/schemas/OrderState/properties/shipping: if is not supported and is ignored",
+		"#/components/schemas/OrderState/properties/shipping: then is not supported and is ignored",
+	}
+	if strings.Join(warnings, "\n") != strings.Join(expectedWarnings, "\n") {
+		t.Errorf("Expected warnings %q, got %q", expectedWarnings, warnings)
+	}
+
+	// $defs are moved to the component schemas
+	orderLine := doc.Components.Schemas["OrderLine"]
+	if orderLine == nil || orderLine.Value == nil {
+		t.Fatalf("Expected the OrderLine $defs schema in components")
+	}
+	if example := orderLine.Value.Properties["sku"].Value.Example; example != "ABC-123" {
+		t.Errorf("Expected the first of the examples as example, got %v", example)
+	}
+
+	model, err := parser.NewOpenAPIParser(doc).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse normalized spec: %v", err)
+	}
+
+	gen := &generator.Generator{}
+	outputDir := "test-output/openapi31"
+	options := generator.GenerationOptions{OptionalPointers: true, GenerateValidation: true}
+	err = gen.GenerateActorPackages(model, outputDir, options)
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	expectedContent := map[string][]string{
+		"order/api.go": {
+			"AddLine(ctx context.Context, request OrderLine) (*OrderState, error)",
+		},
+		"order/types.go": {
+			"Lines []OrderLine `json:\"lines\"`",
+			// Type arrays with null become nullable fields
+			"Note *string `json:\"note,omitempty\"`",
+			"Status *OrderStateStatus `json:\"status,omitempty\"`",
+			// const becomes a single-value enum
+			`OrderStateKindOrder OrderStateKind = "order"`,
+			`CurrencyEUR Currency = "EUR"`,
+			"Position []interface{} `json:\"position,omitempty\"`",
+			"Reference interface{} `json:\"reference,omitempty\"`",
+			// anyOf and oneOf with a null member become the remaining schema
+			"LastLine *OrderLine `json:\"lastLine,omitempty\"`",
+			"Discount *float64 `json:\"discount,omitempty\"`",
+			// Numeric exclusiveMinimum becomes an exclusive minimum
+			"if float64(v.Quantity) <= 0 {",
+		},
+	}
+	for fileName, expected := range expectedContent {
+		content, err := os.ReadFile(filepath.Join(outputDir, fileName))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", fileName, err)
+		}
+		for _, e := range expected {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("Expected '%s' in %s. Got:\n%s", e, fileName, content)
+			}
+		}
+	}
+	content, err := os.ReadFile(filepath.Join(outputDir, "order", "types.go"))
+	if err != nil {
+		t.Fatalf("Failed to read types.go: %v", err)
+	}
+	if strings.Contains(string(content), `OrderStateStatus = ""`) {
+		t.Errorf("Expected no enum constant for null")
+	}
+}
+
+func TestGeneratorWithReminders(t *testing.T) {
+	// Load the reminders spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/reminders.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	// Reminders and timers are attached to the actor sorted by name
+	subscription := model.Actors[0]
+	expectedReminders := []generator.Reminder{
+		{Name: "cleanup", Callback: "OnCleanup"},
+		{Name: "expiry-warning", Callback: "OnExpiryWarning", PayloadType: "OnExpiryWarningPayload"},
+		{Name: "renewal", Callback: "OnRenewal", PayloadType: "RenewalPayload", Comment: "Renews the subscription at the end of the billing period"},
+	}
+	if len(subscription.Reminders) != len(expectedReminders) {
+		t.Fatalf("Expected %d reminders, got %+v", len(expectedReminders), subscription.Reminders)
+	}
+	for i, expected := range expectedReminders {
+		if subscription.Reminders[i] != expected {
+			t.Errorf("Expected reminder %+v, got %+v", expected, subscription.Reminders[i])
+		}
+	}
+	if len(subscription.Timers) != 1 || subscription.Timers[0].Callback != "OnUsageSync" {
+		t.Errorf("Expected the usage-sync timer, got %+v", subscription.Timers)
+	}
+
+	// Payload types are emitted with the actor's types
+	for _, typeName := range []string{"RenewalPayload", "OnExpiryWarningPayload"} {
+		found := false
+		for _, structType := range subscription.Types.Structs {
+			if structType.Name == typeName {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected payload type %s in the Subscription types", typeName)
+		}
+	}
+
+	gen := &generator.Generator{}
+	outputDir := "test-output/reminders"
+	options := generator.GenerationOptions{GenerateMock: true, GenerateImpl: true}
+	err = gen.GenerateActorPackages(model, outputDir, options)
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	expectedContent := map[string][]string{
+		"reminders.go": {
+			`ReminderRenewal       = "renewal"`,
+			`TimerUsageSync        = "usage-sync"`,
+			"func DispatchReminder(ctx context.Context, impl SubscriptionAPI, reminderName string, state []byte) error",
+			"return impl.OnRenewal(ctx, payload)",
+			"return impl.OnCleanup(ctx)",
+			"func (a *RemindingSubscription) ReminderCall(reminderName string, state []byte, dueTime string, period string)",
+			"func (c *SubscriptionClient) RegisterRenewalReminder(ctx context.Context, dueTime, period string, payload RenewalPayload) error",
+			"func (c *SubscriptionClient) UnregisterExpiryWarningReminder(ctx context.Context) error",
+			"func (c *SubscriptionClient) RegisterUsageSyncTimer(ctx context.Context, dueTime, period string) error",
+			`CallBack:  "OnUsageSync",`,
+		},
+		"api.go": {
+			"OnRenewal(ctx context.Context, payload RenewalPayload) error",
+			"OnCleanup(ctx context.Context) error",
+			"OnUsageSync(ctx context.Context) error",
+		},
+		"client.go": {
+			"RegisterRenewalReminder(ctx context.Context, dueTime, period string, payload RenewalPayload) error",
+			"UnregisterUsageSyncTimer(ctx context.Context) error",
+		},
+		"factory.go": {
+			"return &RemindingSubscription{impl}",
+		},
+		"mock.go": {
+			"OnRenewalFunc func(ctx context.Context, payload RenewalPayload) error",
+			"func (m *MockSubscription) RegisterRenewalReminder(ctx context.Context, dueTime, period string, payload RenewalPayload) error",
+			"func (m *MockSubscription) UnregisterUsageSyncTimer(ctx context.Context) error",
+		},
+		"impl.go": {
+			"func (a *Subscription) OnExpiryWarning(ctx context.Context, payload OnExpiryWarningPayload) error",
+			`errors.New("OnCleanup callback is not implemented")`,
+		},
+	}
+	for fileName, expected := range expectedContent {
+		content, err := os.ReadFile(filepath.Join(outputDir, "subscription", fileName))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", fileName, err)
+		}
+		for _, e := range expected {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("Expected '%s' in %s. Got:\n%s", e, fileName, content)
+			}
+		}
+	}
+
+	// The Dapr Go SDK activates the reminding wrapper returned by the factory
+	activateGeneratedActors(t, outputDir, "example-dapr-actors", "subscription")
+}
+
+func TestReminderCallbackConflict(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/reminders.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+	doc.Extensions["x-dapr-timers"] = map[string]interface{}{
+		"Subscription": []interface{}{
+			map[string]interface{}{"name": "status", "callback": "GetStatus"},
+		},
+	}
+
+	_, err = parser.NewOpenAPIParser(doc).Parse()
+	if err == nil || !strings.Contains(err.Error(), "conflicts with an actor method") {
+		t.Errorf("Expected a callback conflict error, got: %v", err)
+	}
+}
+
+func TestGeneratorWithErrorResponses(t *testing.T) {
+	// Load the error responses spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/error-responses.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	// Error responses are ordered by status code with the default response last;
+	// status code ranges and responses without a body are skipped
+	var transfer, closeMethod generator.Method
+	for _, method := range model.Actors[0].Methods {
+		switch method.Name {
+		case "Transfer":
+			transfer = method
+		case "Close":
+			closeMethod = method
+		}
+	}
+	expectedErrors := []generator.ErrorResponse{
+		{StatusCode: 400, TypeName: "ProblemError", BodyType: "Problem", Description: "Invalid transfer"},
+		{StatusCode: 409, TypeName: "InsufficientFundsErrorError", BodyType: "InsufficientFundsError", Description: "Insufficient funds"},
+		{StatusCode: 0, TypeName: "TransferDefaultResponseError", BodyType: "TransferDefaultResponse", Description: "Unexpected error"},
+	}
+	if len(transfer.Errors) != len(expectedErrors) {
+		t.Fatalf("Expected %d error responses, got %+v", len(expectedErrors), transfer.Errors)
+	}
+	for i, expected := range expectedErrors {
+		if transfer.Errors[i] != expected {
+			t.Errorf("Expected error response %+v, got %+v", expected, transfer.Errors[i])
+		}
+	}
+	if len(closeMethod.Errors) != 1 || closeMethod.Errors[0].TypeName != "ProblemError" {
+		t.Errorf("Expected only the 400 error response for Close, got %+v", closeMethod.Errors)
+	}
+
+	// Error types are shared between methods using the same body
+	errorTypes := model.Actors[0].ErrorTypes()
+	if len(errorTypes) != 3 {
+		t.Fatalf("Expected 3 error types, got %+v", errorTypes)
+	}
+
+	gen := &generator.Generator{}
+	outputDir := "test-output/error-responses"
+	err = gen.GenerateActorPackages(model, outputDir, generator.GenerationOptions{})
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	expectedContent := map[string][]string{
+		"errors.go": {
+			"type ProblemError struct",
+			"Body       Problem",
+			"type InsufficientFundsErrorError struct",
+			"type TransferDefaultResponseError struct",
+			"func (e *ProblemError) Error() string",
+			"func DecodeError(err error) error",
+			`case "InsufficientFundsErrorError":`,
+		},
+		"types.go": {
+			"type InsufficientFundsError struct",
+			"type Problem struct",
+			"type TransferDefaultResponse struct",
+		},
+		"api.go": {
+			"// Fails with *InsufficientFundsErrorError for status 409: Insufficient funds",
+			"// Fails with *TransferDefaultResponseError as the default error: Unexpected error",
+		},
+		"client.go": {
+			"DecodeError(err)",
+		},
+	}
+	for fileName, expected := range expectedContent {
+		content, err := os.ReadFile(filepath.Join(outputDir, "wallet", fileName))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", fileName, err)
+		}
+		for _, e := range expected {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("Expected '%s' in %s. Got:\n%s", e, fileName, content)
+			}
+		}
+	}
+}
+
+func TestErrorTypeNameConflict(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/error-responses.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+	doc.Components.Schemas["ProblemError"] = openapi3.NewSchemaRef("", openapi3.NewObjectSchema().
+		WithProperty("code", openapi3.NewStringSchema()))
+
+	_, err = parser.NewOpenAPIParser(doc).Parse()
+	if err == nil || !strings.Contains(err.Error(), "error type ProblemError for the Problem response body of Wallet.") {
+		t.Errorf("Expected an error type name conflict, got: %v", err)
+	}
+}
+
+func TestSuccessResponses(t *testing.T) {
+	// Load the success responses spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/success-responses.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	// The first 2xx response with a body determines the return type
+	expected := map[string]struct {
+		hasReturn  bool
+		returnType string
+	}{
+		"Archive":       {false, ""},
+		"Create":        {true, "DocumentState"},
+		"Get":           {true, "DocumentState"},
+		"Rename":        {false, ""},
+		"GetRevision":   {true, "int64"},
+		"GetTags":       {true, "[]string"},
+		"GetWordCounts": {true, "map[string]int"},
+	}
+	for _, method := range model.Actors[0].Methods {
+		e, ok := expected[method.Name]
+		if !ok {
+			t.Errorf("Unexpected method %s", method.Name)
+			continue
+		}
+		if method.HasReturn != e.hasReturn || method.ReturnType != e.returnType {
+			t.Errorf("Expected %s to have HasReturn=%v and return type '%s', got %v and '%s'",
+				method.Name, e.hasReturn, e.returnType, method.HasReturn, method.ReturnType)
+		}
+	}
+
+	gen := &generator.Generator{}
+	outputDir := "test-output/success-responses"
+	options := generator.GenerationOptions{GenerateImpl: true, GenerateMock: true, GenerateValidation: true}
+	err = gen.GenerateActorPackages(model, outputDir, options)
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	// Methods without a response body only return an error
+	expectedContent := map[string][]string{
+		"api.go": {
+			"Archive(ctx context.Context) error",
+			"Rename(ctx context.Context, request RenameRequest) error",
+			"Create(ctx context.Context, request CreateDocumentRequest) (*DocumentState, error)",
+			// Primitive, array and map bodies map to Go types
+			"GetRevision(ctx context.Context) (*int64, error)",
+			"GetTags(ctx context.Context) (*[]string, error)",
+			"GetWordCounts(ctx context.Context) (*map[string]int, error)",
+		},
+		"client.go": {
+			"func (c *DocumentClient) Archive(ctx context.Context) error {",
+			`_, err = c.invoke(ctx, "Rename", data)`,
+		},
+		"impl.go": {
+			"func (a *Document) Rename(ctx context.Context, request RenameRequest) error {",
+			`return errors.New("Archive method is not implemented")`,
+		},
+		"mock.go": {
+			"ArchiveFunc func(ctx context.Context) error",
+		},
+		"factory.go": {
+			"func (a *ValidatingDocument) Rename(ctx context.Context, request RenameRequest) error {",
+		},
+	}
+	for fileName, expected := range expectedContent {
+		content, err := os.ReadFile(filepath.Join(outputDir, "document", fileName))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", fileName, err)
+		}
+		for _, e := range expected {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("Expected '%s' in %s. Got:\n%s", e, fileName, content)
+			}
+		}
+	}
+}
+
+func TestRequestBodies(t *testing.T) {
+	// Load the request bodies spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/request-bodies.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	// Request bodies are mapped like schema properties; inline objects become <Method>Request
+	expected := map[string]string{
+		"AddEntries":   "[]Entry",
+		"IncrementBy":  "int64",
+		"MergeEntries": "map[string]Entry",
+		"Record":       "RecordRequest",
+		"Rename":       "string",
+		"SetLabels":    "map[string]string",
+	}
+	actor := model.Actors[0]
+	for _, method := range actor.Methods {
+		if !method.HasRequest || method.RequestType != expected[method.Name] {
+			t.Errorf("Expected %s to take a request of type '%s', got '%s'", method.Name, expected[method.Name], method.RequestType)
+		}
+	}
+
+	// Types referenced inside request bodies are emitted with the actor
+	typeNames := make(map[string]bool)
+	for _, structType := range actor.Types.Structs {
+		typeNames[structType.Name] = true
+	}
+	for _, typeName := range []string{"Entry", "RecordRequest"} {
+		if !typeNames[typeName] {
+			t.Errorf("Expected type '%s' in %s actor", typeName, actor.ActorType)
+		}
+	}
+
+	gen := &generator.Generator{}
+	outputDir := "test-output/request-bodies"
+	options := generator.GenerationOptions{GenerateImpl: true, GenerateMock: true, GenerateValidation: true}
+	err = gen.GenerateActorPackages(model, outputDir, options)
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "tally", "api.go"))
+	if err != nil {
+		t.Fatalf("Failed to read api.go: %v", err)
+	}
+	for _, e := range []string{
+		"IncrementBy(ctx context.Context, request int64) (",
+		"AddEntries(ctx context.Context, request []Entry) error",
+		"MergeEntries(ctx context.Context, request map[string]Entry) error",
+	} {
+		if !strings.Contains(string(content), e) {
+			t.Errorf("Expected '%s' in api.go. Got:\n%s", e, content)
+		}
+	}
+}
+
+func TestUnsupportedRequestBody(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/request-bodies.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	// A request body without a JSON schema cannot be mapped to a Go type
+	doc.Paths.Value("/Tally/{actorId}/method/Rename").Post.RequestBody.Value.Content = openapi3.NewContentWithSchema(openapi3.NewStringSchema(), []string{"text/plain"})
+
+	_, err = parser.NewOpenAPIParser(doc).Parse()
+	if err == nil || !strings.Contains(err.Error(), "request body of method Rename") {
+		t.Errorf("Expected an unsupported request body error, got: %v", err)
+	}
+}
+
+func TestAllOfComposition(t *testing.T) {
+	// Load the allOf test OpenAPI spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/all-of.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load allOf OpenAPI spec: %v", err)
+	}
+
+	// Parse the spec to intermediate model
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	if len(model.Actors) != 2 {
+		t.Fatalf("Expected 2 actors, got %d", len(model.Actors))
+	}
+
+	for _, actor := range model.Actors {
+		structs := make(map[string]generator.StructType)
+		for _, structType := range actor.Types.Structs {
+			structs[structType.Name] = structType
+		}
+
+		// The base type must be propagated to every actor using a derived type
+		if _, ok := structs["BaseEvent"]; !ok {
+			t.Errorf("Expected base type 'BaseEvent' in %s actor", actor.ActorType)
+		}
+
+		// Inline allOf parts are flattened with their required lists and descriptions
+		auditEntry, ok := structs["AuditEntry"]
+		if !ok {
+			t.Errorf("Expected type 'AuditEntry' in %s actor", actor.ActorType)
+			continue
+		}
+		if auditEntry.Description != "Entry in the audit log" {
+			t.Errorf("Expected AuditEntry description from allOf part, got '%s'", auditEntry.Description)
+		}
+		fieldTags := make(map[string]string)
+		for _, field := range auditEntry.Fields {
+			fieldTags[field.Name] = field.JSONTag
+		}
+		if fieldTags["Message"] != "message" {
+			t.Errorf("Expected required field Message with tag 'message', got '%s'", fieldTags["Message"])
+		}
+		if fieldTags["Level"] != "level,omitempty" {
+			t.Errorf("Expected optional field Level with tag 'level,omitempty', got '%s'", fieldTags["Level"])
+		}
+	}
+
+	// Referenced allOf parts are embedded, ahead of the derived type's own fields
+	var audit *generator.ActorInterface
+	for i := range model.Actors {
+		if model.Actors[i].ActorType == "Audit" {
+			audit = &model.Actors[i]
+		}
+	}
+	if audit == nil {
+		t.Fatal("Audit actor not found")
+	}
+	for _, structType := range audit.Types.Structs {
+		if structType.Name != "DepositEvent" {
+			continue
+		}
+		if structType.Description != "Money was deposited" {
+			t.Errorf("Expected DepositEvent description 'Money was deposited', got '%s'", structType.Description)
+		}
+		if len(structType.Fields) != 2 {
+			t.Fatalf("Expected DepositEvent to have 2 fields, got %d", len(structType.Fields))
+		}
+		if !structType.Fields[0].Embedded || structType.Fields[0].Type != "BaseEvent" {
+			t.Errorf("Expected first DepositEvent field to embed BaseEvent, got %+v", structType.Fields[0])
+		}
+		if structType.Fields[1].Name != "Amount" || structType.Fields[1].JSONTag != "amount" {
+			t.Errorf("Expected required Amount field, got %+v", structType.Fields[1])
+		}
+	}
+}
+
+func TestDiscriminatedUnions(t *testing.T) {
+	// Load the discriminator test OpenAPI spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/discriminator.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load discriminator OpenAPI spec: %v", err)
+	}
+
+	// Parse the spec to intermediate model
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	if len(model.Actors) != 1 {
+		t.Fatalf("Expected 1 actor, got %d", len(model.Actors))
+	}
+	actor := model.Actors[0]
+
+	unions := make(map[string]generator.UnionType)
+	for _, unionType := range actor.Types.Unions {
+		unions[unionType.Name] = unionType
+	}
+
+	// Inline oneOf property with explicit discriminator mapping
+	eventData, ok := unions["LedgerEventData"]
+	if !ok {
+		t.Fatal("Expected union type 'LedgerEventData' not found")
+	}
+	if eventData.Discriminator != "eventType" {
+		t.Errorf("Expected LedgerEventData discriminator 'eventType', got '%s'", eventData.Discriminator)
+	}
+	expectedVariants := map[string][]string{
+		"AccountOpened": {"opened"},
+		"MoneyMoved":    {"deposited", "withdrawn"},
+	}
+	if len(eventData.Variants) != len(expectedVariants) {
+		t.Errorf("Expected %d LedgerEventData variants, got %d", len(expectedVariants), len(eventData.Variants))
+	}
+	for _, variant := range eventData.Variants {
+		expectedValues, ok := expectedVariants[variant.TypeName]
+		if !ok {
+			t.Errorf("Unexpected LedgerEventData variant '%s'", variant.TypeName)
+			continue
+		}
+		if strings.Join(variant.Values, ",") != strings.Join(expectedValues, ",") {
+			t.Errorf("Expected %s discriminator values %v, got %v", variant.TypeName, expectedValues, variant.Values)
+		}
+	}
+
+	// Component anyOf without mapping uses schema names as discriminator values
+	command, ok := unions["Command"]
+	if !ok {
+		t.Fatal("Expected union type 'Command' not found")
+	}
+	for _, variant := range command.Variants {
+		if len(variant.Values) != 1 || variant.Values[0] != variant.TypeName {
+			t.Errorf("Expected %s discriminator value to default to its schema name, got %v", variant.TypeName, variant.Values)
+		}
+	}
+
+	// The field referencing the inline union uses the union type
+	structs := make(map[string]generator.StructType)
+	for _, structType := range actor.Types.Structs {
+		structs[structType.Name] = structType
+	}
+	for _, field := range structs["LedgerEvent"].Fields {
+		if field.Name == "Data" && field.Type != "LedgerEventData" {
+			t.Errorf("Expected LedgerEvent.Data to have type 'LedgerEventData', got '%s'", field.Type)
+		}
+	}
+
+	// Every variant is emitted into the actor package that uses the union
+	for _, variant := range []string{"AccountOpened", "MoneyMoved", "OpenCommand", "CloseCommand"} {
+		if _, ok := structs[variant]; !ok {
+			t.Errorf("Expected variant type '%s' in Ledger actor", variant)
+		}
+	}
+}
+
+func TestInlineObjectTypes(t *testing.T) {
+	// Load the inline object test OpenAPI spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/inline-objects.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load inline object OpenAPI spec: %v", err)
+	}
+
+	// Parse the spec to intermediate model
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	if len(model.Actors) != 1 {
+		t.Fatalf("Expected 1 actor, got %d", len(model.Actors))
+	}
+	actor := model.Actors[0]
+
+	// Inline request/response bodies become named types
+	expectedSignatures := map[string][2]string{
+		"PlaceOrder": {"PlaceOrderRequest", "PlaceOrderResponse"},
+		"Rename":     {"ShopRenameRequest", "ShopInfo"}, // RenameRequest is taken by a component schema
+		"ListOrders": {"", "[]ListOrdersResponseItem"},
+	}
+	for _, method := range actor.Methods {
+		expected, ok := expectedSignatures[method.Name]
+		if !ok {
+			t.Errorf("Unexpected method '%s'", method.Name)
+			continue
+		}
+		if method.RequestType != expected[0] {
+			t.Errorf("Expected %s request type '%s', got '%s'", method.Name, expected[0], method.RequestType)
+		}
+		if method.ReturnType != expected[1] {
+			t.Errorf("Expected %s return type '%s', got '%s'", method.Name, expected[1], method.ReturnType)
+		}
+	}
+
+	// Inline properties become <Parent><Property> structs emitted into the actor package
+	structs := make(map[string]generator.StructType)
+	for _, structType := range actor.Types.Structs {
+		structs[structType.Name] = structType
+	}
+	for _, name := range []string{
+		"PlaceOrderRequest", "PlaceOrderResponse", "PlaceOrderResponseShipping",
+		"ShopRenameRequest", "ShopInfo", "ShopInfoAddress", "ShopInfoOpeningHoursItem",
+		"ListOrdersResponseItem",
+	} {
+		if _, ok := structs[name]; !ok {
+			t.Errorf("Expected struct type '%s' not found", name)
+		}
+	}
+	if _, ok := structs["RenameRequest"]; ok {
+		t.Error("Unused component 'RenameRequest' should not be emitted")
+	}
+
+	fieldTypes := make(map[string]string)
+	for _, field := range structs["ShopInfo"].Fields {
+		fieldTypes[field.Name] = field.Type
+	}
+	if fieldTypes["Address"] != "ShopInfoAddress" {
+		t.Errorf("Expected ShopInfo.Address type 'ShopInfoAddress', got '%s'", fieldTypes["Address"])
+	}
+	if fieldTypes["OpeningHours"] != "[]ShopInfoOpeningHoursItem" {
+		t.Errorf("Expected ShopInfo.OpeningHours type '[]ShopInfoOpeningHoursItem', got '%s'", fieldTypes["OpeningHours"])
+	}
+}
+
+func TestGeneratorWithOptionalPointers(t *testing.T) {
+	// Load the optional fields spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/optional-fields.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	tests := []struct {
+		name             string
+		optionalPointers bool
+		expectedFields   []string
+	}{
+		{
+			name:             "disabled",
+			optionalPointers: false,
+			expectedFields: []string{
+				"Balance float64 `json:\"balance\"`",
+				"Limits Limits `json:\"limits,omitempty\"`",
+				"OverdraftLimit int `json:\"overdraftLimit,omitempty\"`",
+				"Owner string `json:\"owner\"`",
+				"Tags []string `json:\"tags,omitempty\"`",
+			},
+		},
+		{
+			name:             "enabled",
+			optionalPointers: true,
+			expectedFields: []string{
+				"Balance *float64 `json:\"balance\"`",
+				"Limits *Limits `json:\"limits,omitempty\"`",
+				"OverdraftLimit *int `json:\"overdraftLimit,omitempty\"`",
+				"Owner string `json:\"owner\"`",
+				"Tags []string `json:\"tags,omitempty\"`",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := &generator.Generator{}
+			outputDir := filepath.Join("test-output", "optional-pointers-"+tt.name)
+			options := generator.GenerationOptions{
+				OptionalPointers: tt.optionalPointers,
+			}
+			err = gen.GenerateActorPackages(model, outputDir, options)
+			if err != nil {
+				t.Fatalf("Failed to generate actor packages: %v", err)
+			}
+
+			// Clean up after test
+			defer func() {
+				os.RemoveAll(outputDir)
+			}()
+
+			content, err := os.ReadFile(filepath.Join(outputDir, "wallet", "types.go"))
+			if err != nil {
+				t.Fatalf("Failed to read generated types.go: %v", err)
+			}
+			for _, expected := range tt.expectedFields {
+				if !strings.Contains(string(content), expected) {
+					t.Errorf("Expected field '%s' in types.go. Got:\n%s", expected, content)
+				}
+			}
+		})
+	}
+
+	// The model itself is not modified by generation
+	for _, structType := range model.Actors[0].Types.Structs {
+		for _, field := range structType.Fields {
+			if strings.HasPrefix(field.Type, "*") {
+				t.Errorf("Expected model field %s.%s to remain a value type, got '%s'", structType.Name, field.Name, field.Type)
+			}
+		}
+	}
+}
+
+func TestFormatTypeMappings(t *testing.T) {
+	// Load the format mapping spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/formats.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	// Add a custom mapping on top of the built-in ones
+	format, mapping, err := parser.ParseFormatMapping("decimal=github.com/shopspring/decimal.Decimal")
+	if err != nil {
+		t.Fatalf("Failed to parse format mapping: %v", err)
+	}
+	if format != "decimal" || mapping.Type != "decimal.Decimal" || mapping.Import != "github.com/shopspring/decimal" {
+		t.Fatalf("Unexpected format mapping %s=%+v", format, mapping)
+	}
+
+	p := parser.NewOpenAPIParser(doc).WithFormatMappings(map[string]parser.FormatMapping{format: mapping})
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	expectedTypes := map[string]string{
+		"Id":          "uuid.UUID",
+		"BookedAt":    "time.Time",
+		"ValueDate":   "string",
+		"Sequence":    "int64",
+		"Attachment":  "[]byte",
+		"Amount":      "decimal.Decimal",
+		"Corrections": "[]time.Time",
+	}
+	for _, field := range model.Actors[0].Types.Structs[0].Fields {
+		if expected := expectedTypes[field.Name]; field.Type != expected {
+			t.Errorf("Expected field %s to have type '%s', got '%s'", field.Name, expected, field.Type)
+		}
+	}
+
+	// Generated types.go imports the packages of external types
+	gen := &generator.Generator{}
+	outputDir := "test-output/formats"
+	err = gen.GenerateActorPackages(model, outputDir, generator.GenerationOptions{GenerateImpl: true, GenerateMock: true, GenerateExample: true})
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "ledger", "types.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated types.go: %v", err)
+	}
+	for _, importPath := range []string{`"github.com/google/uuid"`, `"github.com/shopspring/decimal"`, `"time"`} {
+		if !strings.Contains(string(content), importPath) {
+			t.Errorf("Expected import %s in types.go. Got:\n%s", importPath, content)
+		}
+	}
+
+	// Files referencing external types outside types.go import their packages as well
+	for _, fileName := range []string{"api.go", "client.go", "impl.go", "mock.go"} {
+		content, err := os.ReadFile(filepath.Join(outputDir, "ledger", fileName))
+		if err != nil {
+			t.Fatalf("Failed to read generated %s: %v", fileName, err)
+		}
+		if !strings.Contains(string(content), "request uuid.UUID") || !strings.Contains(string(content), `"github.com/google/uuid"`) {
+			t.Errorf("Expected %s to take a uuid.UUID request and import its package. Got:\n%s", fileName, content)
+		}
+	}
+
+	// The example go.mod requires the modules of mapped third-party types
+	goMod, err := os.ReadFile(filepath.Join(outputDir, "go.mod"))
+	if err != nil {
+		t.Fatalf("Failed to read generated go.mod: %v", err)
+	}
+	for _, require := range []string{"github.com/google/uuid v1.6.0", "github.com/shopspring/decimal latest"} {
+		if !strings.Contains(string(goMod), require) {
+			t.Errorf("Expected '%s' in go.mod. Got:\n%s", require, goMod)
+		}
+	}
+	if strings.Contains(string(goMod), "\ttime") {
+		t.Errorf("Expected no requirement for standard library packages. Got:\n%s", goMod)
+	}
+}
+
+func TestGeneratorWithValidation(t *testing.T) {
+	// Load the validation spec
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/validation.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	p := parser.NewOpenAPIParser(doc)
+	model, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	// Constraints are captured in the intermediate model
+	for _, structType := range model.Actors[0].Types.Structs {
+		if structType.Name != "PlaceOrderRequest" {
+			continue
+		}
+		for _, field := range structType.Fields {
+			switch field.Name {
+			case "Customer":
+				if field.Constraints.MinLength != 1 || field.Constraints.MaxLength == nil || *field.Constraints.MaxLength != 50 {
+					t.Errorf("Expected Customer length constraints 1..50, got %+v", field.Constraints)
+				}
+			case "Priority":
+				if strings.Join(field.Constraints.Enum, ",") != "1,2,3" {
+					t.Errorf("Expected Priority enum constraint [1 2 3], got %v", field.Constraints.Enum)
+				}
+			case "Discount":
+				if field.Constraints.Maximum == nil || *field.Constraints.Maximum != 1 || !field.Constraints.ExclusiveMaximum {
+					t.Errorf("Expected Discount exclusive maximum 1, got %+v", field.Constraints)
+				}
+			}
+		}
+	}
+
+	gen := &generator.Generator{}
+	outputDir := "test-output/validation"
+	options := generator.GenerationOptions{
+		GenerateValidation: true,
+		GenerateImpl:       true,
+	}
+	err = gen.GenerateActorPackages(model, outputDir, options)
+	if err != nil {
+		t.Fatalf("Failed to generate actor packages: %v", err)
+	}
+
+	// Clean up after test
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	typesContent, err := os.ReadFile(filepath.Join(outputDir, "order", "types.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated types.go: %v", err)
+	}
+	expectedTypes := []string{
+		"type ValidationErrors []ValidationError",
+		"func (v *PlaceOrderRequest) Validate() error",
+		"func (v PlaceOrderRequestChannel) IsValid() bool",
+		`regexp.MustCompile("^[A-Z]{3}-[0-9]{4}$")`,
+		`errs.add(path+"customer", "must be at least 1 characters long")`,
+		`if float64(v.Discount) >= 1 {`,
+		`errs.add(path+"priority", "must be one of 1, 2, 3")`,
+		`v.Lines[i].validate(path+"lines"+"["+strconv.Itoa(i)+"].", errs)`,
+		`if v.Note != "" {`,
+	}
+	for _, expected := range expectedTypes {
+		if !strings.Contains(string(typesContent), expected) {
+			t.Errorf("Expected '%s' in types.go. Got:\n%s", expected, typesContent)
+		}
+	}
+
+	// The factory wraps the implementation so requests are validated before dispatch
+	factoryContent, err := os.ReadFile(filepath.Join(outputDir, "order", "factory.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated factory.go: %v", err)
+	}
+	expectedFactory := []string{
+		"return &ValidatingOrder{impl}",
+		"func (a *ValidatingOrder) PlaceOrder(ctx context.Context, request PlaceOrderRequest) (*OrderState, error)",
+		"if err := request.Validate(); err != nil {",
+	}
+	for _, expected := range expectedFactory {
+		if !strings.Contains(string(factoryContent), expected) {
+			t.Errorf("Expected '%s' in factory.go. Got:\n%s", expected, factoryContent)
+		}
+	}
+	if strings.Contains(string(factoryContent), "func (a *ValidatingOrder) GetOrder") {
+		t.Errorf("Expected methods without a request not to be wrapped. Got:\n%s", factoryContent)
+	}
+
+	// The Dapr Go SDK activates the validating wrapper returned by the factory
+	activateGeneratedActors(t, outputDir, "example-dapr-actors", "order")
+}
+
+func TestUnsupportedValidationPattern(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/validation.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	// ECMA-262 lookaheads are not supported by Go's regexp package
+	doc.Components.Schemas["PlaceOrderRequest"].Value.Properties["customer"].Value.Pattern = "^(?!foo)[a-z]+$"
+
+	model, err := parser.NewOpenAPIParser(doc).Parse()
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	gen := &generator.Generator{}
+	options := generator.GenerationOptions{GenerateValidation: true}
+	_, err = gen.RenderActorPackages(model, "test-output/invalid-pattern", options)
+	if err == nil || !strings.Contains(err.Error(), "schema PlaceOrderRequest property customer") {
+		t.Errorf("Expected an unsupported pattern error naming the schema and property, got: %v", err)
+	}
+}
+
+func TestFormatMappingQualifiers(t *testing.T) {
+	// The package qualifier is the package name the import path conventionally declares
+	tests := map[string]string{
+		"decimal=github.com/shopspring/decimal.Decimal": "decimal.Decimal",
+		"decimal=github.com/acme/decimal/v2.Decimal":    "decimal.Decimal",
+		"money=github.com/acme/go-money.Money":          "money.Money",
+		"node=gopkg.in/yaml.v3.Node":                    "yaml.Node",
+		"ulid=github.com/oklog/ulid/v2.ULID":            "ulid.ULID",
+	}
+	for value, expected := range tests {
+		_, mapping, err := parser.ParseFormatMapping(value)
+		if err != nil {
+			t.Fatalf("Failed to parse format mapping '%s': %v", value, err)
+		}
+		if mapping.Type != expected {
+			t.Errorf("Expected type %s for '%s', got %s", expected, value, mapping.Type)
+		}
+	}
+}
+
+func TestInvalidFormatMapping(t *testing.T) {
+	for _, value := range []string{"decimal", "=string", "decimal=", "decimal=.Decimal"} {
+		if _, _, err := parser.ParseFormatMapping(value); err == nil {
+			t.Errorf("Expected error for format mapping '%s'", value)
+		}
+	}
+}
+
+// buildGeneratedPackages compiles the generated packages as a module of their own, using the
+// dependency versions pinned by the multi-actor example
+func buildGeneratedPackages(t *testing.T, outputDir, modulePath string) {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	goSum, err := os.ReadFile("../../examples/multi-actors/generated/go.sum")
+	if err != nil {
+		t.Fatalf("Failed to read example go.sum: %v", err)
+	}
+	goMod := "module " + modulePath + "\n\ngo 1.19\n\nrequire github.com/dapr/go-sdk v1.9.0\n"
+	if err := os.WriteFile(filepath.Join(outputDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "go.sum"), goSum, 0644); err != nil {
+		t.Fatalf("Failed to write go.sum: %v", err)
+	}
+
+	cmd := exec.Command("go", "build", "./...")
+	cmd.Dir = outputDir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(output), "dial tcp") || strings.Contains(string(output), "module lookup disabled") {
+			t.Skipf("dependencies of the generated code are not available: %s", output)
+		}
+		t.Fatalf("Generated packages do not compile: %v\n%s", err, output)
+	}
+}
+
+// activateGeneratedActors builds a Dapr actor container from the factory of each of the given generated
+// packages, as the Dapr Go SDK does when an actor is activated
+func activateGeneratedActors(t *testing.T, outputDir, modulePath string, packages ...string) {
+	t.Helper()
+	buildGeneratedPackages(t, outputDir, modulePath)
+
+	var imports, factories []string
+	for _, pkg := range packages {
+		imports = append(imports, fmt.Sprintf("\t%q", modulePath+"/"+pkg))
+		factories = append(factories, "\t\t"+pkg+".NewActorFactory(),")
+	}
+	program := `package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/dapr/go-sdk/actor"
+	actorErr "github.com/dapr/go-sdk/actor/error"
+	"github.com/dapr/go-sdk/actor/manager"
+
+` + strings.Join(imports, "\n") + `
+)
+
+func main() {
+	for _, factory := range []func() actor.ServerContext{
+` + strings.Join(factories, "\n") + `
+	} {
+		if _, err := manager.NewDefaultActorContainerContext(context.Background(), "activation", factory(), nil); err != actorErr.Success {
+			os.Exit(1)
+		}
+	}
+}
+`
+	if err := os.MkdirAll(filepath.Join(outputDir, "activate"), 0755); err != nil {
+		t.Fatalf("Failed to create activation program directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "activate", "main.go"), []byte(program), 0644); err != nil {
+		t.Fatalf("Failed to write activation program: %v", err)
+	}
+
+	// Without a sidecar the Dapr client gives up connecting after the timeout; activation does not need it
+	cmd := exec.Command("go", "run", "./activate")
+	cmd.Dir = outputDir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off", "DAPR_CLIENT_TIMEOUT_SECONDS=1")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Generated actors cannot be activated: %v\n%s", err, output)
+	}
 }